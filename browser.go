@@ -9,6 +9,9 @@ package rod
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"reflect"
 	"sync"
 	"time"
@@ -45,6 +48,7 @@ type Browser struct {
 	slowMotion time.Duration // see defaults.slow
 	trace      bool          // see defaults.Trace
 	monitor    string
+	headless   bool // see defaults.Show
 
 	defaultDevice devices.Device
 
@@ -53,6 +57,11 @@ type Browser struct {
 	event       *goob.Observable // all the browser events from cdp client
 	targetsLock *sync.Mutex
 
+	// the targets of the internal pages created by Browser.withTmpPage, they are hidden from the users.
+	// The ids are kept after the pages are closed, because the events of them may still be queued.
+	tmpPagesLock *sync.RWMutex
+	tmpPages     map[proto.TargetTargetID]struct{}
+
 	// stores all the previous cdp call of same type. Browser doesn't have enough API
 	// for us to retrieve all its internal states. This is an workaround to map them to local.
 	// For example you can't use cdp API to get the current position of mouse.
@@ -70,9 +79,12 @@ func New() *Browser {
 		slowMotion:    defaults.Slow,
 		trace:         defaults.Trace,
 		monitor:       defaults.Monitor,
+		headless:      !defaults.Show,
 		logger:        DefaultLogger,
 		defaultDevice: devices.LaptopWithMDPIScreen.Landescape(),
 		targetsLock:   &sync.Mutex{},
+		tmpPagesLock:  &sync.RWMutex{},
+		tmpPages:      map[proto.TargetTargetID]struct{}{},
 		states:        &sync.Map{},
 	}).WithPanic(utils.Panic)
 }
@@ -90,13 +102,18 @@ func (b *Browser) Incognito() (*Browser, error) {
 	return &incognito, nil
 }
 
-// ControlURL set the url to remote control browser.
+// ControlURL set the url to remote control browser. It's the websocket debugger url of the browser,
+// such as "ws://127.0.0.1:9222/devtools/browser/{id}", it's dialed directly by Browser.Connect,
+// so a remote browser service that only gives a ws or wss endpoint works too.
+// If you only have the http debugger address, such as "127.0.0.1:9222", use launcher.ResolveURL to get the ws url.
 func (b *Browser) ControlURL(url string) *Browser {
 	b.controlURL = url
 	return b
 }
 
-// SlowMotion set the delay for each control action, such as the simulation of the human inputs
+// SlowMotion set the delay for each control action, such as the simulation of the human inputs.
+// Only the input related actions will be delayed, such as mouse, keyboard, touch, or select,
+// other cdp calls such as the network or runtime calls won't be affected.
 func (b *Browser) SlowMotion(delay time.Duration) *Browser {
 	b.slowMotion = delay
 	return b
@@ -114,8 +131,19 @@ func (b *Browser) Monitor(url string) *Browser {
 	return b
 }
 
-// Logger overrides the default log functions for tracing
+// Headless sets whether the local browser launched by Browser.Connect is headless.
+// It's ignored when the ControlURL is set.
+func (b *Browser) Headless(enable bool) *Browser {
+	b.headless = enable
+	return b
+}
+
+// Logger overrides the default log functions for tracing, such as to route the trace output to
+// a structured logger, or to discard it with utils.LoggerQuiet. If l is nil, DefaultLogger will be used.
 func (b *Browser) Logger(l utils.Logger) *Browser {
+	if l == nil {
+		l = DefaultLogger
+	}
 	b.logger = l
 	return b
 }
@@ -141,12 +169,16 @@ func (b *Browser) NoDefaultDevice() *Browser {
 
 // Connect to the browser and start to control it.
 // If fails to connect, try to launch a local browser, if local browser not found try to download one.
+// Use Browser.Headless to control how the local browser is launched.
+// Use Browser.Timeout to limit the time to launch and connect the browser, such as:
+//
+//	browser := rod.New().Timeout(time.Minute).MustConnect().CancelTimeout()
 func (b *Browser) Connect() error {
 	if b.client == nil {
 		u := b.controlURL
 		if u == "" {
 			var err error
-			u, err = launcher.New().Context(b.ctx).Launch()
+			u, err = launcher.New().Headless(b.headless).Context(b.ctx).Launch()
 			if err != nil {
 				return err
 			}
@@ -168,12 +200,23 @@ func (b *Browser) Connect() error {
 	return proto.TargetSetDiscoverTargets{Discover: true}.Call(b)
 }
 
-// Close the browser
+// Close the browser. For an incognito browser, only its browser context will be disposed.
+// If the connection to the browser is already closed, such as the browser crashed or it's closed before,
+// nil will be returned, so it's safe to use it for cleanup multiple times, such as:
+//
+//	defer browser.MustClose()
 func (b *Browser) Close() error {
+	var err error
 	if b.BrowserContextID == "" {
-		return proto.BrowserClose{}.Call(b)
+		err = proto.BrowserClose{}.Call(b)
+	} else {
+		err = proto.TargetDisposeBrowserContext{BrowserContextID: b.BrowserContextID}.Call(b)
 	}
-	return proto.TargetDisposeBrowserContext{BrowserContextID: b.BrowserContextID}.Call(b)
+
+	if errors.Is(err, &cdp.ErrConnectionClosed{}) {
+		return nil
+	}
+	return err
 }
 
 // Page creates a new browser tab. If opts.URL is empty, the default target will be "about:blank".
@@ -207,7 +250,8 @@ func (b *Browser) Page(opts proto.TargetCreateTarget) (p *Page, err error) {
 	return
 }
 
-// Pages retrieves all visible pages
+// Pages retrieves all visible pages.
+// If the browser is an incognito one, only the pages that belong to its browser context will be returned.
 func (b *Browser) Pages() (Pages, error) {
 	list, err := proto.TargetGetTargets{}.Call(b)
 	if err != nil {
@@ -216,7 +260,11 @@ func (b *Browser) Pages() (Pages, error) {
 
 	pageList := Pages{}
 	for _, target := range list.TargetInfos {
-		if target.Type != proto.TargetTargetInfoTypePage {
+		if target.Type != proto.TargetTargetInfoTypePage || b.isTmpPage(target.TargetID) {
+			continue
+		}
+
+		if b.BrowserContextID != "" && target.BrowserContextID != b.BrowserContextID {
 			continue
 		}
 
@@ -261,7 +309,7 @@ func (b *Browser) PageFromTarget(targetID proto.TargetTargetID) (*Page, error) {
 
 	page := b.loadCachedPage(targetID)
 	if page != nil {
-		return page, nil
+		return page.inheritTimeout(b.ctx), nil
 	}
 
 	session, err := proto.TargetAttachToTarget{
@@ -272,12 +320,13 @@ func (b *Browser) PageFromTarget(targetID proto.TargetTargetID) (*Page, error) {
 		return nil, err
 	}
 
-	sessionCtx, cancel := context.WithCancel(b.ctx)
+	sessionCtx, cancel := context.WithCancel(b.withoutTimeout())
 
 	page = &Page{
 		e:             b.e,
 		ctx:           sessionCtx,
 		sessionCancel: cancel,
+		detached:      make(chan struct{}),
 		sleeper:       b.sleeper,
 		browser:       b,
 		TargetID:      targetID,
@@ -306,7 +355,7 @@ func (b *Browser) PageFromTarget(targetID proto.TargetTargetID) (*Page, error) {
 	// Such as proto.PageAddScriptToEvaluateOnNewDocument won't work.
 	page.EnableDomain(&proto.PageEnable{})
 
-	return page, nil
+	return page.inheritTimeout(b.ctx), nil
 }
 
 // EachEvent is similar to Page.EachEvent, but catches events of the entire browser.
@@ -430,7 +479,13 @@ func (b *Browser) Event() <-chan *Message {
 }
 
 func (b *Browser) initEvents() {
-	ctx, cancel := context.WithCancel(b.ctx)
+	// The timeout of the browser should only limit the connecting, not the lifetime of the events.
+	parent := b.ctx
+	if val, ok := parent.Value(timeoutContextKey{}).(*timeoutContextVal); ok {
+		parent = val.parent
+	}
+
+	ctx, cancel := context.WithCancel(parent)
 	b.event = goob.New(ctx)
 	event := b.client.Event()
 
@@ -455,11 +510,36 @@ func (b *Browser) pageInfo(id proto.TargetTargetID) (*proto.TargetTargetInfo, er
 	return res.TargetInfo, nil
 }
 
-// IgnoreCertErrors switch. If enabled, all certificate errors will be ignored.
+// IgnoreCertErrors switch. If enabled, all certificate errors will be ignored, such as the self-signed certs.
+// It's safe to call it multiple times.
 func (b *Browser) IgnoreCertErrors(enable bool) error {
 	return proto.SecuritySetIgnoreCertificateErrors{Ignore: enable}.Call(b)
 }
 
+// GrantPermissions to the origin, such as proto.BrowserPermissionTypeClipboardReadWrite .
+// If the origin is "*", the permissions will be granted to all origins.
+// If the origin or the permissions is empty, ErrInvalidPermissions will be returned.
+// If a permission is unknown to the browser, an error that contains the permission name will be returned.
+func (b *Browser) GrantPermissions(origin string, permissions ...proto.BrowserPermissionType) error {
+	if origin == "" || len(permissions) == 0 {
+		return &ErrInvalidPermissions{origin, permissions}
+	}
+	if origin == "*" {
+		origin = ""
+	}
+
+	return proto.BrowserGrantPermissions{
+		Permissions:      permissions,
+		Origin:           origin,
+		BrowserContextID: b.BrowserContextID,
+	}.Call(b)
+}
+
+// ResetPermissions resets all the permissions granted by Browser.GrantPermissions .
+func (b *Browser) ResetPermissions() error {
+	return proto.BrowserResetPermissions{BrowserContextID: b.BrowserContextID}.Call(b)
+}
+
 // GetCookies from the browser
 func (b *Browser) GetCookies() ([]*proto.NetworkCookie, error) {
 	res, err := proto.StorageGetCookies{BrowserContextID: b.BrowserContextID}.Call(b)
@@ -470,21 +550,193 @@ func (b *Browser) GetCookies() ([]*proto.NetworkCookie, error) {
 }
 
 // SetCookies to the browser. If the cookies is nil it will clear all the cookies.
+// If the browser is an incognito one, only the cookies of its browser context will be set or cleared.
+// Each cookie must have a name and either a url or a domain, or ErrInvalidCookie will be returned.
 func (b *Browser) SetCookies(cookies []*proto.NetworkCookieParam) error {
 	if cookies == nil {
 		return proto.StorageClearCookies{BrowserContextID: b.BrowserContextID}.Call(b)
 	}
 
+	for _, c := range cookies {
+		if c.Name == "" || (c.URL == "" && c.Domain == "") {
+			return &ErrInvalidCookie{c}
+		}
+	}
+
 	return proto.StorageSetCookies{
 		Cookies:          cookies,
 		BrowserContextID: b.BrowserContextID,
 	}.Call(b)
 }
 
+// ClearCache of the browser, such as the http cache of the resources.
+// If the browser is an incognito one, only the cache of its browser context will be cleared.
+// To clear the cookies use Browser.SetCookies with nil.
+func (b *Browser) ClearCache() error {
+	return b.withTmpPage(func(p *Page) error {
+		return proto.NetworkClearBrowserCache{}.Call(p)
+	})
+}
+
+// ServiceWorkers returns the targets of the running service workers.
+// If the browser is an incognito one, only the workers that belong to its browser context will be returned.
+func (b *Browser) ServiceWorkers() ([]*proto.TargetTargetInfo, error) {
+	list, err := proto.TargetGetTargets{}.Call(b)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := []*proto.TargetTargetInfo{}
+	for _, target := range list.TargetInfos {
+		if target.Type != proto.TargetTargetInfoTypeServiceWorker {
+			continue
+		}
+
+		if b.BrowserContextID != "" && target.BrowserContextID != b.BrowserContextID {
+			continue
+		}
+
+		workers = append(workers, target)
+	}
+
+	return workers, nil
+}
+
+// UnregisterServiceWorkers of the origin, such as "http://a.com", the workers will be stopped too.
+// Useful to prevent the cached assets of a service worker from leaking between tests.
+func (b *Browser) UnregisterServiceWorkers(origin string) error {
+	return b.withTmpPage(func(p *Page) error {
+		return proto.StorageClearDataForOrigin{
+			Origin:       origin,
+			StorageTypes: string(proto.StorageStorageTypeServiceWorkers),
+		}.Call(p)
+	})
+}
+
+// StopAllServiceWorkers of the browser. The registrations are kept, a worker will start again
+// when it's required by a page.
+func (b *Browser) StopAllServiceWorkers() error {
+	return b.withTmpPage(func(p *Page) error {
+		err := proto.ServiceWorkerEnable{}.Call(p)
+		if err != nil {
+			return err
+		}
+
+		return proto.ServiceWorkerStopAllWorkers{}.Call(p)
+	})
+}
+
+// withTmpPage creates a blank page in the browser context of the browser, runs fn with it, then closes it.
+// Some domains, such as Network, Storage, and ServiceWorker, are only available for pages,
+// and the page also tells the browser which browser context the call is for.
+// The page is hidden from Browser.Pages, Browser.WaitPage, and Browser.EachPage.
+func (b *Browser) withTmpPage(fn func(*Page) error) error {
+	// hold the lock until the id is recorded, so the handlers of the target created event can't miss it
+	b.tmpPagesLock.Lock()
+	target, err := proto.TargetCreateTarget{URL: "about:blank", BrowserContextID: b.BrowserContextID}.Call(b)
+	if err == nil {
+		b.tmpPages[target.TargetID] = struct{}{}
+	}
+	b.tmpPagesLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	p, err := b.PageFromTarget(target.TargetID)
+	if err != nil {
+		_, _ = proto.TargetCloseTarget{TargetID: target.TargetID}.Call(b)
+		return err
+	}
+	defer func() { _ = p.Close() }()
+
+	return fn(p)
+}
+
+func (b *Browser) isTmpPage(id proto.TargetTargetID) bool {
+	b.tmpPagesLock.RLock()
+	defer b.tmpPagesLock.RUnlock()
+	_, has := b.tmpPages[id]
+	return has
+}
+
+// WaitPage waits for the next new page created in the browser, such as a popup opened by window.open .
+// If the browser is an incognito one, only the pages that belong to its browser context will be waited.
+func (b *Browser) WaitPage() func() (*Page, error) {
+	var targetID proto.TargetTargetID
+
+	wait := b.EachEvent(func(e *proto.TargetTargetCreated) bool {
+		targetID = e.TargetInfo.TargetID
+		return e.TargetInfo.Type == proto.TargetTargetInfoTypePage && !b.isTmpPage(targetID) &&
+			(b.BrowserContextID == "" || e.TargetInfo.BrowserContextID == b.BrowserContextID)
+	})
+
+	return func() (*Page, error) {
+		wait()
+		return b.PageFromTarget(targetID)
+	}
+}
+
+// EachPage calls fn for each new page created in the browser until cancel is called or fn returns an error,
+// such as the tabs created by rod or the popups opened by the site. It's useful to instrument every new page.
+// If the browser is an incognito one, only the pages that belong to its browser context will be handled.
+// The wait returns the error from fn or the page creation, or the context error when it's canceled.
+func (b *Browser) EachPage(fn func(*Page) error) (wait func() error, cancel func()) {
+	origin := b
+	b, cancel = b.WithCancel()
+
+	var err error
+	w := b.EachEvent(func(e *proto.TargetTargetCreated) bool {
+		if e.TargetInfo.Type != proto.TargetTargetInfoTypePage || b.isTmpPage(e.TargetInfo.TargetID) ||
+			(b.BrowserContextID != "" && e.TargetInfo.BrowserContextID != b.BrowserContextID) {
+			return false
+		}
+
+		var page *Page
+		page, err = origin.PageFromTarget(e.TargetInfo.TargetID)
+		if err == nil {
+			err = fn(page)
+		}
+		return err != nil
+	})
+
+	return func() error {
+		defer cancel()
+		w()
+
+		if err != nil {
+			return err
+		}
+		return b.ctx.Err()
+	}, cancel
+}
+
+// SetDownloadPath allows the downloads of the browser and saves the files to the dir with their suggested names.
+// The dir will be created if it doesn't exist. If the browser is an incognito one, only the downloads of its
+// browser context will be affected, so that each context can have its own dir.
+func (b *Browser) SetDownloadPath(dir string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	return proto.BrowserSetDownloadBehavior{
+		Behavior:         proto.BrowserSetDownloadBehaviorBehaviorAllow,
+		BrowserContextID: b.BrowserContextID,
+		DownloadPath:     dir,
+	}.Call(b)
+}
+
 // WaitDownload returns a helper to get the next download file.
 // The file path will be:
 //
 //	filepath.Join(dir, info.GUID)
+//
+// Downloads of blob and data urls are supported too, use info.URL to check which url is downloaded.
 func (b *Browser) WaitDownload(dir string) func() (info *proto.PageDownloadWillBegin) {
 	var oldDownloadBehavior proto.BrowserSetDownloadBehavior
 	has := b.LoadState("", &oldDownloadBehavior)
@@ -521,7 +773,18 @@ func (b *Browser) WaitDownload(dir string) func() (info *proto.PageDownloadWillB
 	}
 }
 
-// Version info of the browser
+// IsConnected returns false if the browser doesn't respond to a cheap cdp call in 5 seconds,
+// such as the browser crashed or the connection is lost. It's useful to evict the dead browsers of a pool.
+func (b *Browser) IsConnected() bool {
+	tb := b.Timeout(5 * time.Second)
+	defer tb.CancelTimeout()
+
+	_, err := tb.Version()
+	return err == nil
+}
+
+// Version info of the browser, such as the protocol version, product, revision, user agent, and js version.
+// The Product looks like "HeadlessChrome/96.0.4664.45", it's useful to check the capabilities of the browser.
 func (b *Browser) Version() (*proto.BrowserGetVersionResult, error) {
 	return proto.BrowserGetVersion{}.Call(b)
 }
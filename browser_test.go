@@ -1,11 +1,15 @@
 package rod_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"testing"
 	"time"
@@ -30,6 +34,10 @@ func TestIncognito(t *testing.T) {
 	defer page.MustClose()
 	page.MustEval(`k => localStorage[k] = 1`, k)
 
+	pages := b.MustPages()
+	g.Len(pages, 1)
+	g.Eq(pages.First().TargetID, page.TargetID)
+
 	g.True(g.page.MustNavigate(g.blank()).MustEval(`k => localStorage[k]`, k).Nil())
 	g.Eq(page.MustEval(`k => localStorage[k]`, k).Str(), "1") // localStorage can only store string
 
@@ -43,6 +51,162 @@ func TestBrowserResetControlURL(t *testing.T) {
 	rod.New().ControlURL("test").ControlURL("")
 }
 
+func TestBrowserVersion(t *testing.T) {
+	g := setup(t)
+
+	v := g.browser.MustVersion()
+	g.Eq(v.ProtocolVersion, "1.3")
+	g.Regex(`^(Headless)?Chrome/\d+\.`, v.Product)
+	g.Neq(v.Revision, "")
+	g.Neq(v.JsVersion, "")
+
+	g.mc.stubErr(1, proto.BrowserGetVersion{})
+	g.Err(g.browser.Version())
+}
+
+func TestBrowserHeadless(t *testing.T) {
+	g := setup(t)
+
+	b := rod.New().Headless(true).Context(g.Context()).MustConnect()
+	defer b.MustClose()
+	g.Has(b.MustVersion().UserAgent, "HeadlessChrome")
+
+	// the flag is ignored when the control url is set
+	u := launcher.New().Context(g.Context()).MustLaunch()
+	rod.New().ControlURL(u).Headless(false).MustConnect().MustClose()
+}
+
+func TestBrowserCloseTwice(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New().Context(g.Context())
+	defer l.Kill()
+
+	b := rod.New().ControlURL(l.MustLaunch()).MustConnect()
+	g.E(b.Close())
+	g.E(b.Close())
+
+	// the browser crashed
+	l = launcher.New().Context(g.Context())
+	b = rod.New().ControlURL(l.MustLaunch()).MustConnect()
+	incognito := b.MustIncognito()
+	l.Kill()
+	l.Cleanup()
+	g.E(incognito.Close())
+	g.E(b.Close())
+}
+
+func TestBrowserIsConnected(t *testing.T) {
+	g := setup(t)
+
+	g.True(g.browser.IsConnected())
+
+	g.mc.stubErr(1, proto.BrowserGetVersion{})
+	g.False(g.browser.IsConnected())
+
+	l := launcher.New().Context(g.Context())
+	b := rod.New().ControlURL(l.MustLaunch()).MustConnect()
+	g.True(b.IsConnected())
+	l.Kill()
+	l.Cleanup()
+	g.False(b.IsConnected())
+}
+
+func TestBrowserControlURLWebSocket(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New().Context(g.Context())
+	defer l.Kill()
+
+	// the raw ws url is dialed directly without the http json endpoint
+	u := l.MustLaunch()
+	g.Regex(`\Aws://127\.0\.0\.1:\d+/devtools/browser/.+\z`, u)
+
+	b := rod.New().ControlURL(u).MustConnect()
+	defer b.MustClose()
+	g.Has(b.MustVersion().Product, "Chrome")
+}
+
+func TestBrowserWaitPage(t *testing.T) {
+	g := setup(t)
+
+	page := g.page.MustNavigate(g.srcFile("fixtures/open-page.html"))
+
+	wait := g.browser.MustWaitPage()
+
+	page.MustElement("a").MustClick()
+
+	newPage := wait()
+	defer newPage.MustClose()
+
+	g.Eq("new page", newPage.MustEval("() => window.a").String())
+}
+
+func TestBrowserEachPage(t *testing.T) {
+	g := setup(t)
+
+	b := g.browser.MustIncognito()
+	defer b.MustClose()
+
+	pages := make(chan *rod.Page)
+	wait, cancel := b.EachPage(func(p *rod.Page) error {
+		pages <- p
+		return nil
+	})
+	done := make(chan error)
+	go func() { done <- wait() }()
+
+	// pages of other browser contexts are ignored
+	g.browser.MustPage().MustClose()
+
+	p := b.MustPage(g.srcFile("fixtures/open-page.html"))
+	g.Eq((<-pages).TargetID, p.TargetID)
+
+	p.MustElement("a").MustClick()
+	popup := <-pages
+	g.Eq(popup.MustInfo().OpenerID, p.TargetID)
+	g.Eq("new page", popup.MustEval("() => window.a").String())
+
+	cancel()
+	g.Eq(<-done, context.Canceled)
+
+	wait, _ = b.EachPage(func(p *rod.Page) error {
+		return errors.New("err")
+	})
+	b.MustPage().MustClose()
+	g.Eq(wait().Error(), "err")
+
+	wait, _ = b.EachPage(func(p *rod.Page) error { return nil })
+	g.mc.stubErr(1, proto.TargetAttachToTarget{})
+	res, err := proto.TargetCreateTarget{URL: "about:blank", BrowserContextID: b.BrowserContextID}.Call(b)
+	g.E(err)
+	g.Err(wait())
+	g.E(proto.TargetCloseTarget{TargetID: res.TargetID}.Call(b))
+}
+
+func TestBrowserPageTimeout(t *testing.T) {
+	g := setup(t)
+
+	_, err := g.browser.Timeout(0).Page(proto.TargetCreateTarget{})
+	g.Is(err, context.DeadlineExceeded)
+
+	p := g.browser.Timeout(300 * time.Millisecond).MustPage()
+	defer p.CancelTimeout().MustClose()
+
+	_, has := p.GetContext().Deadline()
+	g.True(has)
+
+	<-p.GetContext().Done()
+	g.Is(p.Navigate(g.blank()), context.DeadlineExceeded)
+
+	// the timeout doesn't affect the page session
+	g.E(p.CancelTimeout().Navigate(g.blank()))
+	g.E(g.browser.MustPageFromTargetID(p.TargetID).Navigate(g.blank()))
+
+	// the cached page inherits the timeout too
+	g.Is(g.browser.Timeout(0).MustPageFromTargetID(p.TargetID).Navigate(g.blank()), context.DeadlineExceeded)
+}
+
 func TestDefaultDevice(t *testing.T) {
 	g := setup(t)
 
@@ -287,6 +451,52 @@ func TestBinarySize(t *testing.T) {
 	g.Lte(float64(stat.Size())/1024/1024, 10.4) // mb
 }
 
+func TestBrowserIgnoreCertErrors(t *testing.T) {
+	g := setup(t)
+
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer s.Close()
+
+	g.E(g.browser.IgnoreCertErrors(true))
+	g.E(g.browser.IgnoreCertErrors(true)) // calling it twice should be fine
+	defer func() { g.E(g.browser.IgnoreCertErrors(false)) }()
+
+	p := g.newPage(s.URL)
+	g.Eq("ok", p.MustElement("body").MustText())
+}
+
+func TestBrowserGrantPermissions(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve().Route("/", ".html", `<html></html>`)
+	page := g.newPage(s.URL())
+
+	state := func() string {
+		return page.MustEval(`() => navigator.permissions.query({name: 'clipboard-read'}).then(s => s.state)`).Str()
+	}
+
+	g.browser.MustGrantPermissions(s.URL(), proto.BrowserPermissionTypeClipboardReadWrite)
+	g.Eq("granted", state())
+
+	g.browser.MustResetPermissions()
+	g.Eq("prompt", state())
+
+	g.browser.MustGrantPermissions("*", proto.BrowserPermissionTypeClipboardReadWrite)
+	g.Eq("granted", state())
+	g.browser.MustResetPermissions()
+
+	err := g.browser.GrantPermissions("*", "clipboard-typo")
+	g.Has(err.Error(), "clipboard-typo")
+
+	err = g.browser.GrantPermissions("", proto.BrowserPermissionTypeClipboardReadWrite)
+	g.Is(err, &rod.ErrInvalidPermissions{})
+	g.Eq(err.Error(), `permissions must have an origin, or "*" for all origins, and at least one permission: "" [clipboardReadWrite]`)
+
+	g.Is(g.browser.GrantPermissions(s.URL()), &rod.ErrInvalidPermissions{})
+}
+
 func TestBrowserCookies(t *testing.T) {
 	g := setup(t)
 
@@ -313,6 +523,105 @@ func TestBrowserCookies(t *testing.T) {
 
 	g.mc.stubErr(1, proto.StorageGetCookies{})
 	g.Err(b.GetCookies())
+
+	err := b.SetCookies([]*proto.NetworkCookieParam{{Name: "a", Value: "val"}})
+	g.Is(err, &rod.ErrInvalidCookie{})
+	g.Has(err.Error(), "cookie must have a name and either a url or a domain")
+
+	err = b.SetCookies([]*proto.NetworkCookieParam{{Value: "val", URL: "http://test.com"}})
+	g.Is(err, &rod.ErrInvalidCookie{})
+}
+
+func TestBrowserClearCache(t *testing.T) {
+	g := setup(t)
+
+	count := 0
+	s := g.Serve()
+	s.Mux.HandleFunc("/a.js", func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.Header().Set("Cache-Control", "max-age=3600")
+		w.Header().Set("Content-Type", "text/javascript")
+	})
+	s.Route("/page", ".html", `<html><script src="/a.js"></script></html>`)
+	s.Route("/page2", ".html", `<html><script src="/a.js"></script></html>`)
+
+	b := g.browser.MustIncognito()
+	defer b.MustClose()
+
+	p := b.MustPage(s.URL("/page")).MustWaitLoad()
+	p.MustNavigate(s.URL("/page2")).MustWaitLoad()
+	g.Eq(count, 1)
+
+	b.MustClearCache()
+	p.MustNavigate(s.URL("/page")).MustWaitLoad()
+	g.Eq(count, 2)
+
+	g.mc.stubErr(1, proto.TargetCreateTarget{})
+	g.Err(b.ClearCache())
+}
+
+func TestBrowserTmpPageHidden(t *testing.T) {
+	g := setup(t)
+
+	b := g.browser.MustIncognito()
+	defer b.MustClose()
+
+	pages := make(chan *rod.Page, 10)
+	wait, cancel := b.EachPage(func(p *rod.Page) error {
+		pages <- p
+		return nil
+	})
+	defer cancel()
+	go func() { _ = wait() }()
+	waitPage := b.MustWaitPage()
+
+	b.MustClearCache()
+	b.MustStopAllServiceWorkers()
+	g.Len(b.MustPages(), 0)
+
+	// the first page they see should be the one created by the user
+	p := b.MustPage()
+	g.Eq((<-pages).TargetID, p.TargetID)
+	g.Eq(waitPage().TargetID, p.TargetID)
+
+	g.mc.stubErr(1, proto.TargetAttachToTarget{})
+	g.Err(b.ClearCache())
+}
+
+func TestBrowserServiceWorkers(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/sw.js", ".js", `self.addEventListener('fetch', () => {})`)
+	s.Route("/page", ".html", `<html></html>`)
+
+	b := g.browser.MustIncognito()
+	defer b.MustClose()
+
+	p := b.MustPage(s.URL("/page")).MustWaitLoad()
+	p.MustEval(`() => navigator.serviceWorker.register('/sw.js').then(() => navigator.serviceWorker.ready)`)
+
+	list := b.MustServiceWorkers()
+	g.Len(list, 1)
+	g.Eq(list[0].URL, s.URL("/sw.js"))
+	g.Len(g.browser.MustServiceWorkers(), 0)
+
+	b.MustStopAllServiceWorkers()
+
+	b.MustUnregisterServiceWorkers(s.URL())
+	g.Eq(p.MustEval(`() => navigator.serviceWorker.getRegistrations().then(l => l.length)`).Int(), 0)
+
+	g.mc.stubErr(1, proto.TargetGetTargets{})
+	g.Err(b.ServiceWorkers())
+
+	g.mc.stubErr(1, proto.TargetCreateTarget{})
+	g.Err(b.UnregisterServiceWorkers(s.URL()))
+
+	g.mc.stubErr(1, proto.TargetCreateTarget{})
+	g.Err(b.StopAllServiceWorkers())
+
+	g.mc.stubErr(1, proto.ServiceWorkerEnable{})
+	g.Err(b.StopAllServiceWorkers())
 }
 
 func TestWaitDownload(t *testing.T) {
@@ -331,6 +640,45 @@ func TestWaitDownload(t *testing.T) {
 	data := wait()
 
 	g.Eq(content, string(data))
+
+	dir := filepath.Join(os.TempDir(), "rod", "downloads")
+	waitInfo := g.browser.WaitDownload(dir)
+	page.MustElement("a").MustClick()
+	info := waitInfo()
+	defer func() { _ = os.Remove(filepath.Join(dir, info.GUID)) }()
+
+	g.Eq(s.URL("/d"), info.URL)
+}
+
+func TestBrowserSetDownloadPath(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/d.txt", ".bin", "test content")
+	s.Route("/page", ".html", `<html><a href="/d.txt" download>click</a></html>`)
+
+	b := g.browser.MustIncognito()
+	defer b.MustClose()
+
+	dir := filepath.Join(os.TempDir(), "rod", "downloads", g.RandStr(8))
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	b.MustSetDownloadPath(dir)
+
+	page := b.MustPage(s.URL("/page"))
+	wait := b.EachEvent(func(e *proto.PageDownloadProgress) bool {
+		return e.State == proto.PageDownloadProgressStateCompleted
+	})
+	page.MustElement("a").MustClick()
+	wait()
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "d.txt"))
+	g.E(err)
+	g.Eq(string(data), "test content")
+
+	file := filepath.Join(dir, "file")
+	g.E(ioutil.WriteFile(file, nil, 0644))
+	g.Err(b.SetDownloadPath(filepath.Join(file, "dir")))
 }
 
 func TestWaitDownloadDataURI(t *testing.T) {
@@ -438,6 +786,19 @@ func TestBrowserConnectFailure(t *testing.T) {
 	}
 }
 
+func TestBrowserConnectTimeout(t *testing.T) {
+	g := setup(t)
+
+	b := rod.New().Timeout(time.Minute).MustConnect().CancelTimeout()
+	defer b.MustClose()
+
+	// the events should still work after the timeout is canceled
+	page := b.MustPage()
+	wait := page.WaitEvent(&proto.PageFrameNavigated{})
+	page.MustNavigate(g.blank())
+	wait()
+}
+
 func TestBrowserPool(t *testing.T) {
 	pool := rod.NewBrowserPool(3)
 	create := func() *rod.Browser { return rod.New().MustConnect() }
@@ -448,6 +809,30 @@ func TestBrowserPool(t *testing.T) {
 	})
 }
 
+func TestBrowserPoolGetWithContext(t *testing.T) {
+	g := setup(t)
+
+	pool := rod.NewBrowserPool(1)
+	create := func() *rod.Browser { return rod.New().MustConnect() }
+
+	b, err := pool.GetWithContext(g.Context(), create)
+	g.E(err)
+
+	// the pool is exhausted
+	ctx, cancel := context.WithTimeout(g.Context(), 100*time.Millisecond)
+	defer cancel()
+	_, err = pool.GetWithContext(ctx, create)
+	g.Is(err, context.DeadlineExceeded)
+
+	pool.Put(b)
+	g.Eq(b, pool.Get(create))
+	pool.Put(b)
+
+	pool.Cleanup(func(p *rod.Browser) {
+		p.MustClose()
+	})
+}
+
 func TestOldBrowser(t *testing.T) {
 	t.Skip()
 
@@ -474,3 +859,30 @@ func TestBrowserLostConnection(t *testing.T) {
 	_, err := p.Eval(`() => new Promise(r => {})`)
 	g.Err(err)
 }
+
+func TestBrowserEachEventSlowCallback(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	p := g.newPage(s.URL())
+
+	slow, cancel := p.WithCancel()
+	defer cancel()
+
+	// a callback that never returns until the subscription is canceled
+	go slow.EachEvent(func(e *proto.NetworkResponseReceived) {
+		<-slow.GetContext().Done()
+	})()
+
+	count := 0
+	wait := p.EachEvent(func(e *proto.NetworkResponseReceived) bool {
+		count++
+		return count == 3
+	})
+	for i := 0; i < 3; i++ {
+		p.MustEval(`u => fetch(u)`, s.URL())
+	}
+	wait()
+
+	g.Eq(count, 3)
+}
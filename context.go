@@ -25,7 +25,8 @@ func (b *Browser) GetContext() context.Context {
 	return b.ctx
 }
 
-// Timeout returns a clone with the specified total timeout of all chained sub-operations
+// Timeout returns a clone with the specified total timeout of all chained sub-operations.
+// The pages got from the clone inherit the timeout, use Page.CancelTimeout to remove it.
 func (b *Browser) Timeout(d time.Duration) *Browser {
 	ctx, cancel := context.WithTimeout(b.ctx, d)
 	return b.Context(context.WithValue(ctx, timeoutContextKey{}, &timeoutContextVal{b.ctx, cancel}))
@@ -51,7 +52,25 @@ func (b *Browser) Sleeper(sleeper func() utils.Sleeper) *Browser {
 	return &newObj
 }
 
-// Context returns a clone with the specified ctx for chained sub-operations
+// The timeout of the browser should only limit the operations of the pages created from it,
+// not the lifetime of the page sessions, because the pages are cached and shared by all the clones of the browser.
+// It returns the ctx of the browser without the timeouts.
+func (b *Browser) withoutTimeout() context.Context {
+	ctx := b.ctx
+	for {
+		val, ok := ctx.Value(timeoutContextKey{}).(*timeoutContextVal)
+		if !ok {
+			return ctx
+		}
+		ctx = val.parent
+	}
+}
+
+// Context returns a clone with the specified ctx for chained sub-operations.
+// The clone shares the states of the page, such as the cache of the js context, the states are guarded by locks,
+// so it's safe to use the clones in different goroutines, such as:
+//
+//	el, err := page.Timeout(3 * time.Second).Element("button")
 func (p *Page) Context(ctx context.Context) *Page {
 	newObj := *p
 	newObj.ctx = ctx
@@ -76,6 +95,17 @@ func (p *Page) CancelTimeout() *Page {
 	return p.Context(val.parent)
 }
 
+// inheritTimeout returns a clone that has the same timeout as the ctx, Page.CancelTimeout can be used to remove it.
+func (p *Page) inheritTimeout(ctx context.Context) *Page {
+	if _, ok := ctx.Value(timeoutContextKey{}).(*timeoutContextVal); !ok {
+		return p
+	}
+
+	deadline, _ := ctx.Deadline()
+	timeoutCtx, cancel := context.WithDeadline(p.ctx, deadline)
+	return p.Context(context.WithValue(timeoutCtx, timeoutContextKey{}, &timeoutContextVal{p.ctx, cancel}))
+}
+
 // WithCancel returns a clone with a context cancel function
 func (p *Page) WithCancel() (*Page, func()) {
 	ctx, cancel := context.WithCancel(p.ctx)
@@ -89,7 +119,8 @@ func (p *Page) Sleeper(sleeper func() utils.Sleeper) *Page {
 	return &newObj
 }
 
-// Context returns a clone with the specified ctx for chained sub-operations
+// Context returns a clone with the specified ctx for chained sub-operations.
+// The clone still points to the same remote object, only the operations on the clone use the ctx.
 func (el *Element) Context(ctx context.Context) *Element {
 	newObj := *el
 	newObj.ctx = ctx
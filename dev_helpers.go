@@ -92,7 +92,7 @@ func (b *Browser) ServeMonitor(host string) string {
 	return url
 }
 
-// check method and sleep if needed
+// sleep if the slow motion is enabled, it should only be used by input related actions
 func (b *Browser) trySlowmotion() {
 	if b.slowMotion == 0 {
 		return
@@ -210,6 +210,35 @@ func (el *Element) Overlay(msg string) (removeOverlay func()) {
 	return
 }
 
+// Highlight the element with the overlay of the browser, the same one the devtools uses when you hover
+// an element in the Elements panel. The color fills the border box of the element, if it's nil a translucent red
+// will be used. The highlight stays until Element.HideHighlight is called or another element is highlighted,
+// it's useful to draw attention to an element in the screencast of the automation.
+func (el *Element) Highlight(color *proto.DOMRGBA) error {
+	if color == nil {
+		a := 0.4
+		color = &proto.DOMRGBA{R: 255, A: &a}
+	}
+
+	// the Overlay domain requires the DOM domain
+	el.page.EnableDomain(proto.DOMEnable{})
+	el.page.EnableDomain(proto.OverlayEnable{})
+
+	return proto.OverlayHighlightNode{
+		HighlightConfig: &proto.OverlayHighlightConfig{
+			ContentColor: color,
+			PaddingColor: color,
+			BorderColor:  color,
+		},
+		ObjectID: el.id(),
+	}.Call(el)
+}
+
+// HideHighlight removes the highlight created by Element.Highlight
+func (el *Element) HideHighlight() error {
+	return proto.OverlayHideHighlight{}.Call(el)
+}
+
 func (el *Element) tryTrace(typ TraceType, msg ...interface{}) func() {
 	if !el.page.browser.trace {
 		return func() {}
@@ -1,6 +1,7 @@
 package rod_test
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -80,6 +81,37 @@ func TestTrace(t *testing.T) {
 	_ = p.Mouse.MoveTo(proto.NewPoint(10, 10))
 }
 
+func TestElementHighlight(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank())
+	p.MustEval(`() => document.body.innerHTML = '<div style="width: 100px; height: 100px"></div>'`)
+	el := p.MustElement("div")
+
+	el.MustHighlight(nil).MustHideHighlight()
+	el.MustHighlight(&proto.DOMRGBA{G: 255})
+
+	g.mc.stubErr(1, proto.OverlayHighlightNode{})
+	g.Err(el.Highlight(nil))
+
+	g.mc.stubErr(1, proto.OverlayHideHighlight{})
+	g.Err(el.HideHighlight())
+}
+
+func TestTraceDefaultLogger(t *testing.T) {
+	g := setup(t)
+
+	buf := bytes.NewBuffer(nil)
+	defer rod.DefaultLogger.SetOutput(rod.DefaultLogger.Writer())
+	rod.DefaultLogger.SetOutput(buf)
+	g.browser.Logger(nil).Trace(true)
+	defer g.browser.Trace(defaults.Trace)
+
+	g.page.MustNavigate(g.srcFile("fixtures/click.html")).MustElement("button").MustClick()
+
+	g.Has(buf.String(), "left click")
+}
+
 func TestTraceLogs(t *testing.T) {
 	g := setup(t)
 
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"reflect"
+	"regexp"
 	"strings"
 	"time"
 
@@ -50,7 +52,8 @@ func (el *Element) Page() *Page {
 	return el.page
 }
 
-// Focus sets focus on the specified element.
+// Focus sets focus on the specified element, the same as the js element.focus(),
+// so the "focus" and "focusin" events of the element will be triggered.
 // Before the action, it will try to scroll to the element.
 func (el *Element) Focus() error {
 	err := el.ScrollIntoView()
@@ -78,6 +81,7 @@ func (el *Element) ScrollIntoView() error {
 
 // Hover the mouse over the center of the element.
 // Before the action, it will try to scroll to the element and wait until it's interactable.
+// It dispatches a real mouse move event, so the css ":hover" state will be applied, such as to open a dropdown menu.
 func (el *Element) Hover() error {
 	pt, err := el.WaitInteractable()
 	if err != nil {
@@ -100,6 +104,7 @@ func (el *Element) MoveMouseOut() error {
 // Click will press then release the button just like a human.
 // Before the action, it will try to scroll to the element, hover the mouse over it,
 // wait until the it's interactable and enabled.
+// If the element has no visible shape after the scroll, ErrInvisibleShape will be returned and no click will happen.
 func (el *Element) Click(button proto.InputMouseButton, clickCount int) error {
 	err := el.Hover()
 	if err != nil {
@@ -201,6 +206,18 @@ func (el *Element) Shape() (*proto.DOMGetContentQuadsResult, error) {
 	return proto.DOMGetContentQuads{ObjectID: el.id()}.Call(el)
 }
 
+// Box returns the border box of the element, it includes the padding and border but not the margin.
+// The values are in css pixels relative to the viewport of the main frame, so the device scale factor
+// doesn't affect them and they are in the same coordinate system as the mouse.
+// If the element is transformed, such as rotated, the box is the smallest leveled rectangle that covers it.
+func (el *Element) Box() (*proto.DOMRect, error) {
+	res, err := proto.DOMGetBoxModel{ObjectID: el.id()}.Call(el)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Shape{res.Model.Border}.Box(), nil
+}
+
 // Type is similar with Keyboard.Type.
 // Before the action, it will try to scroll to the element and focus on it.
 func (el *Element) Type(keys ...input.Key) error {
@@ -255,12 +272,28 @@ func (el *Element) SelectAllText() error {
 // Input focuses on the element and input text to it.
 // Before the action, it will scroll to the element, wait until it's visible, enabled and writable.
 // To empty the input you can use something like el.SelectAllText().MustInput("")
+// If the element is a select, the option whose text is exactly the same as the text will be selected,
+// check Element.Select for details.
 func (el *Element) Input(text string) error {
-	err := el.Focus()
+	err := el.ScrollIntoView()
 	if err != nil {
 		return err
 	}
 
+	// focus and check the tag in one call, so that the normal inputs don't pay for the select support
+	res, err := el.Evaluate(Eval(`() => { this.focus(); return this.tagName === 'SELECT' }`).ByUser())
+	if err != nil {
+		return err
+	}
+	if res.Value.Bool() {
+		err = el.Select([]string{"^" + regexp.QuoteMeta(text) + "$"}, true, SelectorTypeRegex)
+		var notFound *ErrOptionNotFound
+		if errors.As(err, &notFound) {
+			notFound.Selectors = []string{text}
+		}
+		return err
+	}
+
 	err = el.WaitEnabled()
 	if err != nil {
 		return err
@@ -301,7 +334,8 @@ func (el *Element) InputTime(t time.Time) error {
 	return err
 }
 
-// Blur is similar to the method Blur
+// Blur removes the focus from the element, the same as the js element.blur(),
+// so the "blur" and "focusout" events of the element will be triggered if it has the focus.
 func (el *Element) Blur() error {
 	_, err := el.Evaluate(Eval("() => this.blur()").ByUser())
 	return err
@@ -309,7 +343,8 @@ func (el *Element) Blur() error {
 
 // Select the children option elements that match the selectors.
 // Before the action, it will scroll to the element, wait until it's visible.
-// If no option matches the selectors, it will return ErrElementNotFound.
+// For a multiple select element, all the matched options will be selected.
+// If any of the selectors matches no option, it will return ErrOptionNotFound with the unmatched selectors.
 func (el *Element) Select(selectors []string, selected bool, t SelectorType) error {
 	err := el.Focus()
 	if err != nil {
@@ -323,8 +358,12 @@ func (el *Element) Select(selectors []string, selected bool, t SelectorType) err
 	if err != nil {
 		return err
 	}
-	if !res.Value.Bool() {
-		return &ErrElementNotFound{}
+	if list := res.Value.Arr(); len(list) > 0 {
+		selectors := []string{}
+		for _, s := range list {
+			selectors = append(selectors, s.Str())
+		}
+		return &ErrOptionNotFound{selectors}
 	}
 	return nil
 }
@@ -338,7 +377,7 @@ func (el *Element) Matches(selector string) (bool, error) {
 	return res.Value.Bool(), nil
 }
 
-// Attribute of the DOM object.
+// Attribute of the DOM object, returns nil if the attribute doesn't exist.
 // Attribute vs Property: https://stackoverflow.com/questions/6003819/what-is-the-difference-between-properties-and-attributes-in-html
 func (el *Element) Attribute(name string) (*string, error) {
 	attr, err := el.Eval("(n) => this.getAttribute(n)", name)
@@ -354,7 +393,7 @@ func (el *Element) Attribute(name string) (*string, error) {
 	return &s, nil
 }
 
-// Property of the DOM object.
+// Property of the DOM object, it reflects the live state of the DOM, such as the current value of an input.
 // Property vs Attribute: https://stackoverflow.com/questions/6003819/what-is-the-difference-between-properties-and-attributes-in-html
 func (el *Element) Property(name string) (gson.JSON, error) {
 	prop, err := el.Eval("(n) => this[n]", name)
@@ -365,10 +404,39 @@ func (el *Element) Property(name string) (gson.JSON, error) {
 	return prop.Value, nil
 }
 
-// SetFiles of the current file input element
+// SetFiles of the current file input element.
+// Each path must be an existing readable file, or ErrNotFile will be returned for a directory. If the input doesn't have the "multiple" attribute,
+// only one path is allowed, or ErrNotMultipleFiles will be returned.
 func (el *Element) SetFiles(paths []string) error {
 	absPaths := utils.AbsolutePaths(paths)
 
+	for _, p := range absPaths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return &ErrNotFile{p}
+		}
+
+		// make sure the browser can read it
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		_ = f.Close()
+	}
+
+	if len(absPaths) > 1 {
+		multiple, err := el.Property("multiple")
+		if err != nil {
+			return err
+		}
+		if !multiple.Bool() {
+			return &ErrNotMultipleFiles{len(absPaths)}
+		}
+	}
+
 	defer el.tryTrace(TraceTypeInput, fmt.Sprintf("set files: %v", absPaths))()
 	el.page.browser.trySlowmotion()
 
@@ -412,7 +480,13 @@ func (el *Element) ShadowRoot() (*Element, error) {
 	return el.page.ElementFromObject(shadowNode.Object)
 }
 
-// Frame creates a page instance that represents the iframe
+// Frame creates a page instance that represents the iframe, the queries and evaluations on it
+// will run inside the iframe, such as:
+//
+//	page.MustElement("iframe").MustFrame().MustElement("button")
+//
+// Cross-origin iframes work too, because the launcher disables the "site-per-process" feature by default
+// so that the iframe shares the same target as its parent page.
 func (el *Element) Frame() (*Page, error) {
 	node, err := el.Describe(1, false)
 	if err != nil {
@@ -437,7 +511,9 @@ func (el *Element) ContainsElement(target *Element) (bool, error) {
 	return res.Value.Bool(), nil
 }
 
-// Text that the element displays
+// Text that the element displays. It's the innerText of the element, so hidden content is excluded and
+// line breaks are kept. For input and textarea elements it's the value, or the placeholder if the value is empty.
+// For select elements it's the text of the selected options joined by commas.
 func (el *Element) Text() (string, error) {
 	str, err := el.Evaluate(evalHelper(js.Text))
 	if err != nil {
@@ -446,7 +522,7 @@ func (el *Element) Text() (string, error) {
 	return str.Value.String(), nil
 }
 
-// HTML of the element
+// HTML of the element, it's the outerHTML of the live DOM node
 func (el *Element) HTML() (string, error) {
 	res, err := proto.DOMGetOuterHTML{ObjectID: el.Object.ObjectID}.Call(el)
 	if err != nil {
@@ -455,7 +531,7 @@ func (el *Element) HTML() (string, error) {
 	return res.OuterHTML, nil
 }
 
-// Visible returns true if the element is visible on the page
+// Visible returns true if the element is visible on the page. Check Element.WaitVisible for what is visible.
 func (el *Element) Visible() (bool, error) {
 	res, err := el.Evaluate(evalHelper(js.Visible))
 	if err != nil {
@@ -566,7 +642,12 @@ func (el *Element) Wait(opts *EvalOptions) error {
 	return el.page.Context(el.ctx).Sleeper(el.sleeper).Wait(opts.This(el.Object))
 }
 
-// WaitVisible until the element is visible
+// WaitVisible until the element is visible.
+// An element is visible when it has a non-zero size, its computed style isn't
+// "display: none" or "visibility: hidden", and neither it nor any of its ancestors has "opacity: 0".
+// The opacity check also applies to Element.Visible, Element.WaitInvisible, and Page.WaitInvisible,
+// a transparent element used to be treated as visible.
+// The timeout comes from the context of the element, such as Element.Timeout.
 func (el *Element) WaitVisible() error {
 	defer el.tryTrace(TraceTypeWait, "visible")()
 	return el.Wait(evalHelper(js.Visible))
@@ -586,7 +667,8 @@ func (el *Element) WaitWritable() error {
 	return el.Wait(Eval(`() => !this.readonly`))
 }
 
-// WaitInvisible until the element invisible
+// WaitInvisible until the element is invisible or removed from the DOM. Check Element.WaitVisible for what is visible.
+// The timeout comes from the context of the element, such as Element.Timeout.
 func (el *Element) WaitInvisible() error {
 	defer el.tryTrace(TraceTypeWait, "invisible")()
 	return el.Wait(evalHelper(js.Invisible))
@@ -653,12 +735,20 @@ func (el *Element) Screenshot(format proto.PageCaptureScreenshotFormat, quality
 
 	box := shape.Box()
 
+	// the screenshot is in device pixels, the box is in css pixels,
+	// the ratio covers both the emulated device scale factor and the HiDPI display
+	ratio, err := el.Eval(`() => window.devicePixelRatio`)
+	if err != nil {
+		return nil, err
+	}
+	scale := ratio.Value.Num()
+
 	// TODO: proto.PageCaptureScreenshot has a Clip option, but it's buggy, so now we do in Go.
 	return utils.CropImage(bin, quality,
-		int(box.X),
-		int(box.Y),
-		int(box.Width),
-		int(box.Height),
+		int(box.X*scale),
+		int(box.Y*scale),
+		int(box.Width*scale),
+		int(box.Height*scale),
 	)
 }
 
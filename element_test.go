@@ -2,12 +2,15 @@ package rod_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"image/color"
 	"image/png"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +18,7 @@ import (
 	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/devices"
 	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/launcher"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
 	"github.com/ysmood/gson"
@@ -59,6 +63,17 @@ func TestClickWrapped(t *testing.T) {
 	g.True(p.MustHas("[a=ok]"))
 }
 
+func TestClickInvisibleShape(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/interactable.html"))
+	pos := p.Mouse.Position()
+
+	err := p.MustElement("#no-shape").Click(proto.InputMouseButtonLeft, 1)
+	g.Is(err, &rod.ErrInvisibleShape{})
+	g.Eq(p.Mouse.Position(), pos)
+}
+
 func TestTap(t *testing.T) {
 	g := setup(t)
 
@@ -193,6 +208,22 @@ func TestWaitInteractable(t *testing.T) {
 	g.Err(el.WaitInteractable())
 }
 
+func TestHoverCSS(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank())
+	p.MustEval(`() => document.body.innerHTML =
+		'<style>#sub { display: none } #menu:hover #sub { display: block }</style>' +
+		'<div id="menu">menu<a id="sub">sub</a></div>'`)
+
+	sub := p.MustElement("#sub")
+	g.False(sub.MustVisible())
+
+	p.MustElement("#menu").MustHover()
+	g.True(sub.MustVisible())
+	g.True(p.MustElement("#menu").MustMatches(":hover"))
+}
+
 func TestHover(t *testing.T) {
 	g := setup(t)
 
@@ -237,6 +268,23 @@ func TestElementContext(t *testing.T) {
 	el.Sleeper(rod.DefaultSleeper).MustClick()
 }
 
+func TestElementTimeoutClone(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.srcFile("fixtures/click.html"))
+	el := p.MustElement("button")
+	el.MustEval(`function () { this.style.display = 'none' }`)
+
+	clone := el.Timeout(100 * time.Millisecond)
+	g.Eq(clone.Object.ObjectID, el.Object.ObjectID)
+	g.Is(clone.WaitVisible(), context.DeadlineExceeded)
+
+	// the element and its page are not affected
+	el.MustEval(`function () { this.style.display = '' }`)
+	el.MustWaitVisible()
+	p.MustElement("button")
+}
+
 func TestIframes(t *testing.T) {
 	g := setup(t)
 
@@ -252,6 +300,50 @@ func TestIframes(t *testing.T) {
 	g.True(frame02.MustHas("[a=ok]"))
 }
 
+func TestIframeCrossOrigin(t *testing.T) {
+	g := setup(t)
+
+	child := g.Serve()
+	child.Route("/", ".html", `<html><button>child</button></html>`)
+	childURL := strings.Replace(child.URL(), "127.0.0.1", "localhost", 1)
+
+	parent := g.Serve()
+	parent.Route("/", ".html", fmt.Sprintf(`<html><iframe src="%s"></iframe></html>`, childURL))
+
+	p := g.newPage(parent.URL())
+	frame := p.MustElement("iframe").MustFrame()
+
+	g.Eq("child", frame.MustElement("button").MustText())
+	g.Has(frame.MustEval(`() => location.href`).Str(), childURL)
+}
+
+func TestPageFrames(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/click-iframes.html"))
+	p.MustElement("iframe").MustFrame().MustElement("iframe").MustFrame().MustElement("button")
+
+	list := p.MustFrames()
+	g.Len(list, 3)
+	g.Eq(list[0].ID, p.FrameID)
+	g.Eq(list[0].ParentID, proto.PageFrameID(""))
+	g.Eq(list[1].ParentID, list[0].ID)
+	g.Has(list[2].URL, "click.html")
+
+	g.Eq(p.MustFrameByID(list[0].ID), p)
+	frame := p.MustFrameByID(list[2].ID)
+	g.True(frame.IsIframe())
+	frame.MustElement("button").MustClick()
+	g.True(frame.MustHas("[a=ok]"))
+
+	g.mc.stubErr(1, proto.PageGetFrameTree{})
+	g.Err(p.Frames())
+	g.mc.stubErr(1, proto.DOMGetFrameOwner{})
+	g.Err(p.FrameByID(list[1].ID))
+	g.mc.stubErr(1, proto.DOMResolveNode{})
+	g.Err(p.FrameByID(list[1].ID))
+}
+
 func TestContains(t *testing.T) {
 	g := setup(t)
 
@@ -343,6 +435,25 @@ func TestCheckbox(t *testing.T) {
 	g.True(el.MustClick().MustProperty("checked").Bool())
 }
 
+func TestElementTextAndHTML(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank())
+	p.MustEval(`() => document.body.innerHTML =
+		'<div id="a">a<br>b<span style="display:none">hidden</span></div>' +
+		'<input value="in"><textarea placeholder="holder"></textarea>'`)
+
+	el := p.MustElement("#a")
+	g.Eq("a\nb", el.MustText())
+	g.Eq(`<div id="a">a<br>b<span style="display:none">hidden</span></div>`, el.MustHTML())
+
+	g.Eq("in", p.MustElement("input").MustText())
+	g.Eq("holder", p.MustElement("textarea").MustText())
+
+	g.mc.stubErr(1, proto.DOMGetOuterHTML{})
+	g.Err(el.HTML())
+}
+
 func TestSelectText(t *testing.T) {
 	g := setup(t)
 
@@ -386,6 +497,31 @@ func TestBlur(t *testing.T) {
 	g.Eq("ok", *el.MustAttribute("a"))
 }
 
+func TestFocusBlurEvents(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank())
+	p.MustEval(`() => {
+		document.body.innerHTML = '<input>'
+		window.events = []
+		const el = document.querySelector('input')
+		for (const name of ['focus', 'focusin', 'blur', 'focusout']) {
+			el.addEventListener(name, () => window.events.push(name))
+		}
+	}`)
+
+	el := p.MustElement("input").MustFocus()
+	g.True(el.MustEval(`() => document.activeElement === this`).Bool())
+
+	el.MustBlur()
+	g.False(el.MustEval(`() => document.activeElement === this`).Bool())
+
+	g.Eq(p.MustEval(`() => window.events.join(' ')`).Str(), "focus focusin blur focusout")
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(el.Blur())
+}
+
 func TestSelectQuery(t *testing.T) {
 	g := setup(t)
 
@@ -397,6 +533,29 @@ func TestSelectQuery(t *testing.T) {
 	g.Eq(2, el.MustEval("() => this.selectedIndex").Int())
 }
 
+func TestInputSelect(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/input.html"))
+	el := p.MustElement("select")
+	el.MustEval(`() => this.onchange = () => this.setAttribute('event', 'select-change')`)
+
+	el.MustInput("B")
+	g.Eq("B", el.MustText())
+	g.True(p.MustHas("[event=select-change]"))
+
+	g.Is(el.Input("not-exists"), &rod.ErrElementNotFound{})
+
+	// only the option with the exact same text matches
+	el.MustEval(`() => this.innerHTML = '<option>Pineapple</option><option>Apple</option>'`)
+	el.MustInput("Apple")
+	g.Eq(el.MustEval(`() => Array.from(this.selectedOptions).map(o => o.text).join()`).Str(), "Apple")
+	g.Eq(el.Input("ple").Error(), "cannot find option for: ple")
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(el.Input("B"))
+}
+
 func TestSelectOptions(t *testing.T) {
 	g := setup(t)
 
@@ -419,6 +578,11 @@ func TestSelectOptions(t *testing.T) {
 	// option not found error
 	g.Is(el.Select([]string{"not-exists"}, true, rod.SelectorTypeCSSSector), &rod.ErrElementNotFound{})
 
+	err = el.Select([]string{"B", "x", "y"}, true, rod.SelectorTypeText)
+	g.Is(err, &rod.ErrOptionNotFound{})
+	g.Eq(err.Error(), "cannot find option for: x; y")
+	g.Eq("B", el.MustText())
+
 	{
 		g.mc.stubErr(5, proto.RuntimeCallFunctionOn{})
 		g.Err(el.Select([]string{"B"}, true, rod.SelectorTypeText))
@@ -461,6 +625,20 @@ func TestAttribute(t *testing.T) {
 	})
 }
 
+func TestAttributeVsProperty(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank())
+	p.MustEval(`() => document.body.innerHTML = '<input value="a" data-id="1">'`)
+	el := p.MustElement("input")
+	el.MustEval(`() => this.value = 'b'`)
+
+	g.Eq("a", *el.MustAttribute("value"))
+	g.Eq("b", el.MustProperty("value").Str())
+	g.Eq("1", *el.MustAttribute("data-id"))
+	g.Eq("1", el.MustProperty("dataset").Get("id").Str())
+}
+
 func TestProperty(t *testing.T) {
 	g := setup(t)
 
@@ -500,6 +678,40 @@ func TestSetFiles(t *testing.T) {
 	g.Eq("alert.html", list[1].String())
 }
 
+func TestSetFilesErr(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/input.html"))
+	el := p.MustElement(`[type=file]`)
+
+	err := el.SetFiles([]string{slash("fixtures/not-exists.html")})
+	g.Is(err, os.ErrNotExist)
+
+	err = el.SetFiles([]string{slash("fixtures")})
+	g.Is(err, &rod.ErrNotFile{})
+	g.Eq(err.Error(), "the path is a directory, not a file: "+utils.AbsolutePaths([]string{slash("fixtures")})[0])
+
+	unreadable := filepath.Join("tmp", g.RandStr(16)+".txt")
+	g.E(utils.OutputFile(unreadable, ""))
+	g.E(os.Chmod(unreadable, 0))
+	if f, err := os.Open(unreadable); err == nil { // such as the root user
+		_ = f.Close()
+	} else {
+		g.Is(el.SetFiles([]string{unreadable}), os.ErrPermission)
+	}
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(el.SetFiles([]string{slash("fixtures/click.html"), slash("fixtures/alert.html")}))
+
+	el.MustEval(`() => this.multiple = false`)
+	err = el.SetFiles([]string{slash("fixtures/click.html"), slash("fixtures/alert.html")})
+	g.Is(err, &rod.ErrNotMultipleFiles{})
+	g.Eq(err.Error(), "the file input doesn't accept multiple files, but got 2 files")
+
+	el.MustSetFiles(slash("fixtures/click.html"))
+	g.Eq(1, el.MustEval("() => this.files.length").Int())
+}
+
 func TestEnter(t *testing.T) {
 	g := setup(t)
 
@@ -534,6 +746,47 @@ func TestWaitInvisible(t *testing.T) {
 	g.False(p.MustHas("h4"))
 }
 
+func TestWaitVisibleOpacity(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/click.html"))
+	btn := p.MustElement("button")
+
+	btn.MustEval(`() => this.style.opacity = '0'`)
+	g.False(btn.MustVisible())
+
+	go func() {
+		utils.Sleep(0.03)
+		btn.MustEval(`() => this.style.opacity = ''`)
+	}()
+
+	g.Eq(p.MustWaitVisible("button").MustText(), btn.MustText())
+	g.True(btn.MustVisible())
+
+	// a transparent parent hides the element too
+	btn.MustEval(`() => this.parentElement.style.opacity = '0'`)
+	g.False(btn.MustVisible())
+}
+
+func TestPageWaitVisibleDisplayNone(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank())
+	p.MustEval(`() => {
+		let el = document.createElement('div')
+		el.id = 'box'
+		el.innerText = 'ok'
+		el.style.display = 'none'
+		document.body.append(el)
+		setTimeout(() => el.style.display = '', 30)
+	}`)
+
+	g.Eq(p.MustWaitVisible("#box").MustText(), "ok")
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.WaitVisible("#box"))
+}
+
 func TestWaitEnabled(t *testing.T) {
 	g := setup(t)
 
@@ -681,6 +934,43 @@ func TestElementScreenshot(t *testing.T) {
 		g.mc.stubErr(3, proto.DOMGetContentQuads{})
 		el.MustScreenshot()
 	})
+	g.Panic(func() {
+		g.mc.stub(1, proto.PageCaptureScreenshot{}, func(send StubSend) (gson.JSON, error) {
+			g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+			return send()
+		})
+		el.MustScreenshot()
+	})
+}
+
+func TestElementScreenshotDeviceScaleFactor(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.srcFile("fixtures/click.html"))
+	g.E(p.SetViewport(&proto.EmulationSetDeviceMetricsOverride{
+		Width:             800,
+		Height:            600,
+		DeviceScaleFactor: 2,
+	}))
+
+	data := p.MustElement("h4").MustScreenshot()
+	img, err := png.Decode(bytes.NewBuffer(data))
+	g.E(err)
+	g.Eq(400, img.Bounds().Dx())
+	g.Eq(60, img.Bounds().Dy())
+
+	// a HiDPI display without the emulation
+	l := launcher.New().Context(g.Context()).Set("force-device-scale-factor", "2")
+	defer l.Kill()
+
+	b := rod.New().ControlURL(l.MustLaunch()).MustConnect()
+	defer b.MustClose()
+
+	data = b.MustPage(g.srcFile("fixtures/click.html")).MustElement("h4").MustScreenshot()
+	img, err = png.Decode(bytes.NewBuffer(data))
+	g.E(err)
+	g.Eq(400, img.Bounds().Dx())
+	g.Eq(60, img.Bounds().Dy())
 }
 
 func TestUseReleasedElement(t *testing.T) {
@@ -808,6 +1098,20 @@ func TestShapeInIframe(t *testing.T) {
 	g.InDelta(pt.Y, 287, 1)
 }
 
+func TestElementBox(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank())
+	p.MustEval(`() => document.body.innerHTML = '<div style="position: absolute; left: 10px; top: 20px; ' +
+		'width: 100px; height: 50px; padding: 5px; border: 2px solid; margin: 3px"></div>'`)
+
+	g.Eq(p.MustElement("div").MustBox(), &proto.DOMRect{X: 13, Y: 23, Width: 114, Height: 64})
+
+	el := p.MustElement("div")
+	g.mc.stubErr(1, proto.DOMGetBoxModel{})
+	g.Err(el.Box())
+}
+
 func TestElementFromPointErr(t *testing.T) {
 	g := setup(t)
 
@@ -3,6 +3,7 @@ package rod
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
@@ -53,6 +54,75 @@ func (e *ErrExpectElements) Error() string {
 // Is interface
 func (e *ErrExpectElements) Is(err error) bool { _, ok := err.(*ErrExpectElements); return ok }
 
+// ErrNotMultipleFiles error
+type ErrNotMultipleFiles struct {
+	Count int
+}
+
+func (e *ErrNotMultipleFiles) Error() string {
+	return fmt.Sprintf("the file input doesn't accept multiple files, but got %d files", e.Count)
+}
+
+// Is interface
+func (e *ErrNotMultipleFiles) Is(err error) bool { _, ok := err.(*ErrNotMultipleFiles); return ok }
+
+// ErrRequestFailed error
+type ErrRequestFailed struct {
+	URL       string
+	ErrorText string
+}
+
+func (e *ErrRequestFailed) Error() string {
+	return fmt.Sprintf("request failed: %s %s", e.ErrorText, e.URL)
+}
+
+// Is interface
+func (e *ErrRequestFailed) Is(err error) bool { _, ok := err.(*ErrRequestFailed); return ok }
+
+// ErrDownloadPathNotSet error. Use Browser.SetDownloadPath to set it.
+type ErrDownloadPathNotSet struct{}
+
+func (e *ErrDownloadPathNotSet) Error() string {
+	return "the download path of the browser is not set"
+}
+
+// Is interface
+func (e *ErrDownloadPathNotSet) Is(err error) bool { _, ok := err.(*ErrDownloadPathNotSet); return ok }
+
+// ErrDownloadCanceled error
+type ErrDownloadCanceled struct {
+	URL string
+}
+
+func (e *ErrDownloadCanceled) Error() string {
+	return fmt.Sprintf("download canceled: %s", e.URL)
+}
+
+// Is interface
+func (e *ErrDownloadCanceled) Is(err error) bool { _, ok := err.(*ErrDownloadCanceled); return ok }
+
+// ErrNoStorageOrigin error. The frame has no security origin to get the web storage, such as before any navigation.
+type ErrNoStorageOrigin struct{}
+
+func (e *ErrNoStorageOrigin) Error() string {
+	return "the frame has no security origin for the web storage"
+}
+
+// Is interface
+func (e *ErrNoStorageOrigin) Is(err error) bool { _, ok := err.(*ErrNoStorageOrigin); return ok }
+
+// ErrConsoleErrors error. The errors recorded by Page.FailOnConsoleError, each one has its js stack if known.
+type ErrConsoleErrors struct {
+	Errors []string
+}
+
+func (e *ErrConsoleErrors) Error() string {
+	return fmt.Sprintf("page has %d console error(s):\n%s", len(e.Errors), strings.Join(e.Errors, "\n"))
+}
+
+// Is interface
+func (e *ErrConsoleErrors) Is(err error) bool { _, ok := err.(*ErrConsoleErrors); return ok }
+
 // ErrElementNotFound error
 type ErrElementNotFound struct {
 }
@@ -61,6 +131,27 @@ func (e *ErrElementNotFound) Error() string {
 	return "cannot find element"
 }
 
+// Is interface
+func (e *ErrElementNotFound) Is(err error) bool { _, ok := err.(*ErrElementNotFound); return ok }
+
+// ErrOptionNotFound error, it's also an ErrElementNotFound
+type ErrOptionNotFound struct {
+	Selectors []string
+}
+
+func (e *ErrOptionNotFound) Error() string {
+	return fmt.Sprintf("cannot find option for: %s", strings.Join(e.Selectors, "; "))
+}
+
+// Is interface
+func (e *ErrOptionNotFound) Is(err error) bool {
+	switch err.(type) {
+	case *ErrOptionNotFound, *ErrElementNotFound:
+		return true
+	}
+	return false
+}
+
 // NotFoundSleeper returns ErrElementNotFound on the first call
 func NotFoundSleeper() utils.Sleeper {
 	return func(context.Context) error {
@@ -105,6 +196,17 @@ func (e *ErrNavigation) Error() string {
 // Is interface
 func (e *ErrNavigation) Is(err error) bool { _, ok := err.(*ErrNavigation); return ok }
 
+// ErrNoHistoryEntry error
+type ErrNoHistoryEntry struct {
+}
+
+func (e *ErrNoHistoryEntry) Error() string {
+	return "no history entry to navigate to"
+}
+
+// Is interface
+func (e *ErrNoHistoryEntry) Is(err error) bool { _, ok := err.(*ErrNoHistoryEntry); return ok }
+
 // ErrPageCloseCanceled error
 type ErrPageCloseCanceled struct {
 }
@@ -113,6 +215,46 @@ func (e *ErrPageCloseCanceled) Error() string {
 	return "page close canceled"
 }
 
+// ErrPageClosed error
+type ErrPageClosed struct {
+	err error
+}
+
+func (e *ErrPageClosed) Error() string {
+	return "page closed"
+}
+
+// Unwrap stdlib interface
+func (e *ErrPageClosed) Unwrap() error {
+	return e.err
+}
+
+// Is interface
+func (e *ErrPageClosed) Is(err error) bool { _, ok := err.(*ErrPageClosed); return ok }
+
+// ErrNotFile error
+type ErrNotFile struct {
+	Path string
+}
+
+func (e *ErrNotFile) Error() string {
+	return fmt.Sprintf("the path is a directory, not a file: %s", e.Path)
+}
+
+// Is interface
+func (e *ErrNotFile) Is(err error) bool { _, ok := err.(*ErrNotFile); return ok }
+
+// ErrReloadCanceled error
+type ErrReloadCanceled struct {
+}
+
+func (e *ErrReloadCanceled) Error() string {
+	return "page reload canceled"
+}
+
+// Is interface
+func (e *ErrReloadCanceled) Is(err error) bool { _, ok := err.(*ErrReloadCanceled); return ok }
+
 // ErrNotInteractable error. Check the doc of Element.Interactable for details.
 type ErrNotInteractable struct{}
 
@@ -181,3 +323,40 @@ type ErrPageNotFound struct {
 func (e *ErrPageNotFound) Error() string {
 	return "cannot find page"
 }
+
+// ErrInvalidCookie error
+type ErrInvalidCookie struct {
+	*proto.NetworkCookieParam
+}
+
+func (e *ErrInvalidCookie) Error() string {
+	return fmt.Sprintf("cookie must have a name and either a url or a domain: %s", utils.MustToJSON(e.NetworkCookieParam))
+}
+
+// Is interface
+func (e *ErrInvalidCookie) Is(err error) bool { _, ok := err.(*ErrInvalidCookie); return ok }
+
+// ErrStitchFormat error
+type ErrStitchFormat struct {
+	Format proto.PageCaptureScreenshotFormat
+}
+
+func (e *ErrStitchFormat) Error() string {
+	return fmt.Sprintf("the stitched screenshot only supports png and jpeg, but got: %s", e.Format)
+}
+
+// Is interface
+func (e *ErrStitchFormat) Is(err error) bool { _, ok := err.(*ErrStitchFormat); return ok }
+
+// ErrInvalidPermissions error
+type ErrInvalidPermissions struct {
+	Origin      string
+	Permissions []proto.BrowserPermissionType
+}
+
+func (e *ErrInvalidPermissions) Error() string {
+	return fmt.Sprintf(`permissions must have an origin, or "*" for all origins, and at least one permission: %q %v`, e.Origin, e.Permissions)
+}
+
+// Is interface
+func (e *ErrInvalidPermissions) Is(err error) bool { _, ok := err.(*ErrInvalidPermissions); return ok }
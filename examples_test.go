@@ -189,6 +189,24 @@ func Example_error_handling() {
 	}
 }
 
+// Shows how to rebuild the browser when the connection to it is lost, such as the browser restarted.
+// The pages of the old connection are invalid, they have to be recreated with the new browser.
+func Example_reconnect() {
+	connect := func() *rod.Browser {
+		u := launcher.MustResolveURL("127.0.0.1:9222")
+		return rod.New().ControlURL(u).MustConnect()
+	}
+
+	browser := connect()
+
+	_, err := browser.Pages()
+	if errors.Is(err, &cdp.ErrConnectionClosed{}) {
+		browser = connect()
+	}
+
+	fmt.Println(browser.MustPages())
+}
+
 // Example_search shows how to use Search to get element inside nested iframes or shadow DOMs.
 // It works the same as https://developers.google.com/web/tools/chrome-devtools/dom#search
 func Example_search() {
@@ -310,6 +328,27 @@ func Example_wait_for_request() {
 	// Output: true
 }
 
+// Shows how to press named keys and shortcuts such as Tab, Enter, or Ctrl+A.
+// The named keys are defined in the lib/input package, KeyActions will keep the modifier
+// pressed while the other keys are typed.
+func Example_key_combinations() {
+	browser := rod.New().MustConnect()
+	defer browser.MustClose()
+
+	page := browser.MustPage("").MustSetDocumentContent(`<input value="lisp">`)
+
+	el := page.MustElement("input")
+
+	// Use Ctrl+A to select all the text, then use Backspace to delete it
+	el.MustKeyActions().
+		Press(input.ControlLeft).Type('a').Release(input.ControlLeft).
+		Type(input.Backspace).MustDo()
+
+	fmt.Println(el.MustText() == "")
+
+	// Output: true
+}
+
 // Shows how to change the retry/polling options that is used to query elements.
 // This is useful when you want to customize the element query retry logic.
 func Example_customize_retry_strategy() {
@@ -542,7 +581,10 @@ func ExamplePage_pool() {
 
 	yourJob := func() {
 		page := pool.Get(create)
-		defer pool.Put(page)
+		defer func() {
+			// Reset the page so that it can be reused by the next job
+			pool.Put(page.MustNavigate(""))
+		}()
 
 		page.MustNavigate("http://mdn.dev").MustWaitLoad()
 		fmt.Println(page.MustInfo().Title)
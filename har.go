@@ -0,0 +1,338 @@
+package rod
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// StartHAR starts to record the network traffic of the page. Call stop to end the recording and get the
+// HAR 1.2 json, it can be opened by tools like the "Network" panel of Chrome DevTools.
+// The response bodies are included if the browser still holds them when the requests finish.
+// Spec: http://www.softwareishard.com/blog/har-12-spec
+func (p *Page) StartHAR() (stop func() ([]byte, error)) {
+	restore := p.EnableDomain(proto.NetworkEnable{})
+
+	r := &harRecorder{entries: []*harRecord{}, pending: map[proto.NetworkRequestID]*harRecord{}}
+	ep, cancel := p.WithCancel()
+	wait := ep.EachEvent(func(e *proto.NetworkRequestWillBeSent) {
+		r.requestWillBeSent(e)
+	}, func(e *proto.NetworkResponseReceived) {
+		if rec, has := r.pending[e.RequestID]; has {
+			rec.setResponse(e.Response)
+		}
+	}, func(e *proto.NetworkLoadingFinished) {
+		if rec, has := r.pending[e.RequestID]; has {
+			delete(r.pending, e.RequestID)
+			rec.finish(e.Timestamp)
+
+			body, err := proto.NetworkGetResponseBody{RequestID: e.RequestID}.Call(ep)
+			if err == nil {
+				rec.setBody(body)
+			}
+		}
+	}, func(e *proto.NetworkLoadingFailed) {
+		if rec, has := r.pending[e.RequestID]; has {
+			delete(r.pending, e.RequestID)
+			rec.finish(e.Timestamp)
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wait()
+	}()
+
+	return func() ([]byte, error) {
+		cancel()
+		<-done
+		restore()
+
+		return json.Marshal(r.har())
+	}
+}
+
+type harRecorder struct {
+	entries []*harRecord
+	pending map[proto.NetworkRequestID]*harRecord
+}
+
+type harRecord struct {
+	entry  *harEntry
+	start  proto.MonotonicTime
+	timing *proto.NetworkResourceTiming
+}
+
+func (r *harRecorder) requestWillBeSent(e *proto.NetworkRequestWillBeSent) {
+	// a redirect reuses the request id, the previous entry ends with the redirect response
+	if rec, has := r.pending[e.RequestID]; has && e.RedirectResponse != nil {
+		rec.setResponse(e.RedirectResponse)
+		rec.entry.Response.RedirectURL = e.Request.URL
+		rec.finish(e.Timestamp)
+	}
+
+	req := e.Request
+	u := req.URL + req.URLFragment
+
+	entry := &harEntry{
+		StartedDateTime: e.WallTime.Time().UTC().Format(time.RFC3339Nano),
+		Request: &harRequest{
+			Method:      req.Method,
+			URL:         u,
+			Cookies:     []interface{}{},
+			Headers:     harHeaders(req.Headers),
+			QueryString: harQueryString(u),
+			HeadersSize: -1,
+			BodySize:    len(req.PostData),
+		},
+		Cache: struct{}{},
+		Timings: &harTimings{
+			DNS:     -1,
+			Connect: -1,
+			SSL:     -1,
+		},
+	}
+
+	if req.PostData != "" {
+		entry.Request.PostData = &harPostData{
+			MIMEType: harHeader(req.Headers, "Content-Type"),
+			Text:     req.PostData,
+		}
+	}
+
+	rec := &harRecord{entry: entry, start: e.Timestamp}
+	r.entries = append(r.entries, rec)
+	r.pending[e.RequestID] = rec
+}
+
+func (rec *harRecord) setResponse(res *proto.NetworkResponse) {
+	version := harHTTPVersion(res.Protocol)
+
+	if len(res.RequestHeaders) > 0 {
+		rec.entry.Request.Headers = harHeaders(res.RequestHeaders)
+	}
+	rec.entry.Request.HTTPVersion = version
+	rec.entry.ServerIPAddress = strings.Trim(res.RemoteIPAddress, "[]")
+	rec.timing = res.Timing
+
+	rec.entry.Response = &harResponse{
+		Status:      res.Status,
+		StatusText:  res.StatusText,
+		HTTPVersion: version,
+		Cookies:     []interface{}{},
+		Headers:     harHeaders(res.Headers),
+		Content: &harContent{
+			MIMEType: res.MIMEType,
+		},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+}
+
+func (rec *harRecord) setBody(body *proto.NetworkGetResponseBodyResult) {
+	if rec.entry.Response == nil {
+		return
+	}
+
+	content := rec.entry.Response.Content
+	content.Text = body.Body
+	content.Size = len(body.Body)
+
+	if body.Base64Encoded {
+		content.Encoding = "base64"
+		content.Size = base64.StdEncoding.DecodedLen(len(body.Body)) - strings.Count(body.Body, "=")
+	}
+}
+
+// finish calculates the timings of the entry, the times are in milliseconds
+func (rec *harRecord) finish(end proto.MonotonicTime) {
+	t := rec.entry.Timings
+	total := harMS(end - rec.start)
+
+	if rec.timing == nil {
+		t.Receive = total
+		rec.entry.Time = total
+		return
+	}
+
+	tm := rec.timing
+	t.Blocked = math.Max(0, firstNonNegative(tm.DNSStart, tm.ConnectStart, tm.SendStart))
+	if tm.DNSStart >= 0 {
+		t.DNS = tm.DNSEnd - tm.DNSStart
+	}
+	if tm.ConnectStart >= 0 {
+		t.Connect = tm.ConnectEnd - tm.ConnectStart
+	}
+	if tm.SslStart >= 0 {
+		t.SSL = tm.SslEnd - tm.SslStart
+	}
+	t.Send = tm.SendEnd - tm.SendStart
+	t.Wait = tm.ReceiveHeadersEnd - tm.SendEnd
+	t.Receive = math.Max(0, harMS(end)-tm.RequestTime*1000-tm.ReceiveHeadersEnd)
+
+	// the ssl time is included in the connect time
+	rec.entry.Time = t.Blocked + math.Max(0, t.DNS) + math.Max(0, t.Connect) + t.Send + t.Wait + t.Receive
+}
+
+func (r *harRecorder) har() *har {
+	entries := []*harEntry{}
+	for _, rec := range r.entries {
+		if rec.entry.Response != nil {
+			entries = append(entries, rec.entry)
+		}
+	}
+
+	return &har{Log: &harLog{
+		Version: "1.2",
+		Creator: &harCreator{Name: "rod", Version: "0"},
+		Entries: entries,
+	}}
+}
+
+func harMS(t proto.MonotonicTime) float64 {
+	return float64(t) * 1000
+}
+
+func firstNonNegative(list ...float64) float64 {
+	for _, v := range list {
+		if v >= 0 {
+			return v
+		}
+	}
+	return -1
+}
+
+func harHTTPVersion(protocol string) string {
+	switch protocol {
+	case "h2":
+		return "HTTP/2.0"
+	case "h3":
+		return "HTTP/3.0"
+	}
+	return strings.ToUpper(protocol)
+}
+
+func harHeaders(headers proto.NetworkHeaders) []*harPair {
+	list := []*harPair{}
+	for k, v := range headers {
+		list = append(list, &harPair{k, v.String()})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+func harHeader(headers proto.NetworkHeaders, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v.String()
+		}
+	}
+	return ""
+}
+
+func harQueryString(u string) []*harPair {
+	raw := ""
+	if i := strings.Index(u, "?"); i >= 0 {
+		raw = strings.SplitN(u[i+1:], "#", 2)[0]
+	}
+	query, _ := url.ParseQuery(raw)
+
+	keys := []string{}
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	list := []*harPair{}
+	for _, k := range keys {
+		for _, v := range query[k] {
+			list = append(list, &harPair{k, v})
+		}
+	}
+	return list
+}
+
+type har struct {
+	Log *harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string      `json:"version"`
+	Creator *harCreator `json:"creator"`
+	Entries []*harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string       `json:"startedDateTime"`
+	Time            float64      `json:"time"`
+	Request         *harRequest  `json:"request"`
+	Response        *harResponse `json:"response"`
+	Cache           struct{}     `json:"cache"`
+	Timings         *harTimings  `json:"timings"`
+	ServerIPAddress string       `json:"serverIPAddress,omitempty"`
+}
+
+type harRequest struct {
+	Method      string        `json:"method"`
+	URL         string        `json:"url"`
+	HTTPVersion string        `json:"httpVersion"`
+	Cookies     []interface{} `json:"cookies"`
+	Headers     []*harPair    `json:"headers"`
+	QueryString []*harPair    `json:"queryString"`
+	PostData    *harPostData  `json:"postData,omitempty"`
+	HeadersSize int           `json:"headersSize"`
+	BodySize    int           `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int           `json:"status"`
+	StatusText  string        `json:"statusText"`
+	HTTPVersion string        `json:"httpVersion"`
+	Cookies     []interface{} `json:"cookies"`
+	Headers     []*harPair    `json:"headers"`
+	Content     *harContent   `json:"content"`
+	RedirectURL string        `json:"redirectURL"`
+	HeadersSize int           `json:"headersSize"`
+	BodySize    int           `json:"bodySize"`
+}
+
+type harPair struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MIMEType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MIMEType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
@@ -0,0 +1,86 @@
+package rod_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/ysmood/gson"
+)
+
+func TestPageHAR(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/page", http.StatusFound)
+	})
+	s.Route("/page", ".html", `<html><img src="/icon.png"></html>`)
+	s.Route("/icon.png", slash("fixtures/icon.png"))
+	s.Mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		g.E(err)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	})
+
+	p := g.newPage(g.blank())
+
+	stop := p.MustStartHAR()
+
+	wait := p.MustWaitRequestIdle()
+	p.MustNavigate(s.URL("/redirect")).MustWaitLoad()
+	p.MustEval(`() => fetch('/api?b=2&a=1#hash', {
+		method: 'POST',
+		headers: { 'Content-Type': 'application/json' },
+		body: '{"a":1}',
+	}).then(res => res.text())`)
+	p.MustEval(`() => fetch('http://127.0.0.1:1/refused').catch(() => {})`)
+	wait()
+
+	har := gson.New(stop())
+	g.Eq(har.Get("log.version").Str(), "1.2")
+
+	entries := map[string]gson.JSON{}
+	for _, e := range har.Get("log.entries").Arr() {
+		entries[e.Get("request.url").Str()] = e
+	}
+
+	// the failed request has no response
+	_, has := entries["http://127.0.0.1:1/refused"]
+	g.False(has)
+
+	redirect := entries[s.URL("/redirect")]
+	g.Eq(redirect.Get("response.status").Int(), http.StatusFound)
+	g.Eq(redirect.Get("response.redirectURL").Str(), s.URL("/page"))
+
+	page := entries[s.URL("/page")]
+	g.Eq(page.Get("request.method").Str(), "GET")
+	g.Eq(page.Get("response.status").Int(), http.StatusOK)
+	g.Eq(page.Get("response.httpVersion").Str(), "HTTP/1.1")
+	g.Eq(page.Get("response.content.text").Str(), `<html><img src="/icon.png"></html>`)
+	g.Gt(page.Get("time").Num(), 0)
+	g.Gt(page.Get("timings.wait").Num(), 0)
+
+	icon, err := ioutil.ReadFile(slash("fixtures/icon.png"))
+	g.E(err)
+	img := entries[s.URL("/icon.png")]
+	g.Eq(img.Get("response.content.encoding").Str(), "base64")
+	g.Eq(img.Get("response.content.size").Int(), len(icon))
+
+	api := entries[s.URL("/api?b=2&a=1#hash")]
+	g.Eq(api.Get("request.method").Str(), "POST")
+	g.Eq(api.Get("request.queryString").Arr()[0].Get("name").Str(), "a")
+	g.Eq(api.Get("request.postData.mimeType").Str(), "application/json")
+	g.Eq(api.Get("request.postData.text").Str(), `{"a":1}`)
+	g.Eq(api.Get("response.content.mimeType").Str(), "application/json")
+	g.Eq(api.Get("response.content.text").Str(), `{"a":1}`)
+
+	hasHeader := false
+	for _, h := range api.Get("response.headers").Arr() {
+		if h.Get("name").Str() == "Content-Type" {
+			hasHeader = true
+		}
+	}
+	g.True(hasHeader)
+}
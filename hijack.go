@@ -100,8 +100,14 @@ func (r *HijackRouter) initEvents() *HijackRouter {
 				err := ctx.Response.payload.Call(r.client)
 				if err != nil {
 					ctx.OnError(err)
-					return
 				}
+				return
+			}
+
+			// no handler has handled the request, continue it so that the page won't hang
+			err := proto.FetchContinueRequest{RequestID: e.RequestID}.Call(r.client)
+			if err != nil {
+				ctx.OnError(err)
 			}
 		}()
 
@@ -112,6 +118,7 @@ func (r *HijackRouter) initEvents() *HijackRouter {
 
 // Add a hijack handler to router, the doc of the pattern is the same as "proto.FetchRequestPattern.URLPattern".
 // You can add new handler even after the "Run" is called.
+// If all the matched handlers skip a request, the request will be continued without hijacking.
 func (r *HijackRouter) Add(pattern string, resourceType proto.NetworkResourceType, handler func(*Hijack)) error {
 	r.enable.Patterns = append(r.enable.Patterns, &proto.FetchRequestPattern{
 		URLPattern:   pattern,
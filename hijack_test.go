@@ -212,6 +212,48 @@ func TestHijackSkip(t *testing.T) {
 	wg.Wait()
 }
 
+func TestHijackAllSkipped(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve().Route("/a", ".html", `<body>ok</body>`)
+
+	router := g.page.HijackRequests()
+	defer router.MustStop()
+
+	router.MustAdd(s.URL("/a"), func(ctx *rod.Hijack) {
+		ctx.Skip = true
+	})
+
+	go router.Run()
+
+	g.page.MustNavigate(s.URL("/a"))
+
+	g.Eq("ok", g.page.MustElement("body").MustText())
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	var err error
+
+	router.MustAdd(s.URL("/b"), func(ctx *rod.Hijack) {
+		ctx.OnError = func(e error) {
+			err = e
+			wg.Done()
+		}
+		ctx.Skip = true
+	})
+
+	g.mc.stub(1, proto.FetchContinueRequest{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(nil), errors.New("err")
+	})
+
+	go func() {
+		_ = g.page.Context(g.Context()).Navigate(s.URL("/b"))
+	}()
+	wg.Wait()
+
+	g.Eq(err.Error(), "err")
+}
+
 func TestHijackOnErrorLog(t *testing.T) {
 	g := setup(t)
 
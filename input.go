@@ -70,9 +70,19 @@ func (k *Keyboard) Release(key input.Key) error {
 	return key.Encode(proto.InputDispatchKeyEventTypeKeyUp, k.modifiers()).Call(k.page)
 }
 
-// Type releases the key after the press
+// Type releases the key after the press.
+// If a key is not defined in the key map, such as an emoji or an accented character,
+// it will be sent as a char event with its text instead.
 func (k *Keyboard) Type(keys ...input.Key) (err error) {
 	for _, key := range keys {
+		if !key.Defined() {
+			err = k.typeChar(key)
+			if err != nil {
+				return
+			}
+			continue
+		}
+
 		err = k.Press(key)
 		if err != nil {
 			return
@@ -85,6 +95,21 @@ func (k *Keyboard) Type(keys ...input.Key) (err error) {
 	return
 }
 
+func (k *Keyboard) typeChar(key input.Key) error {
+	txt := string(key)
+
+	defer k.page.tryTrace(TraceTypeInput, "type char: "+txt)()
+	k.page.browser.trySlowmotion()
+
+	return proto.InputDispatchKeyEvent{
+		Type:           proto.InputDispatchKeyEventTypeChar,
+		Key:            txt,
+		Text:           txt,
+		UnmodifiedText: txt,
+		Modifiers:      k.getModifiers(),
+	}.Call(k.page)
+}
+
 // KeyActionType enum
 type KeyActionType int
 
@@ -285,7 +310,9 @@ func (m *Mouse) MoveLinear(to proto.Point, steps int) error {
 	})
 }
 
-// Scroll the relative offset with specified steps
+// Scroll the relative offset with specified steps.
+// It dispatches real mouse wheel events at the current mouse position, unlike Page.ScrollTo,
+// so it works for components that only listen to the wheel events, such as virtualized lists.
 func (m *Mouse) Scroll(offsetX, offsetY float64, steps int) error {
 	m.Lock()
 	defer m.Unlock()
@@ -389,6 +416,32 @@ func (m *Mouse) Click(button proto.InputMouseButton, clickCount int) error {
 	return m.Up(button, clickCount)
 }
 
+// Drag presses the left button at from, moves to the to with the specified steps, then releases the button.
+// Some drag-and-drop libraries need the intermediate mouse move events to register the drag,
+// so the steps should be larger than 1 for them.
+func (m *Mouse) Drag(from, to proto.Point, steps int) error {
+	if steps < 1 {
+		steps = 1
+	}
+
+	err := m.MoveTo(from)
+	if err != nil {
+		return err
+	}
+
+	err = m.Down(proto.InputMouseButtonLeft, 1)
+	if err != nil {
+		return err
+	}
+
+	err = m.MoveLinear(to, steps)
+	if err != nil {
+		return err
+	}
+
+	return m.Up(proto.InputMouseButtonLeft, 1)
+}
+
 // Touch presents a touch device, such as a hand with fingers, each finger is a proto.InputTouchPoint.
 // Touch events is stateless, we use the struct here only as a namespace to make the API style unified.
 type Touch struct {
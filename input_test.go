@@ -2,6 +2,7 @@ package rod_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/go-rod/rod/lib/devices"
 	"github.com/go-rod/rod/lib/input"
@@ -39,6 +40,22 @@ func TestKeyActions(t *testing.T) {
 	g.Nil(p.Keyboard.Release('a'))
 }
 
+func TestKeyCombinations(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/", ".html", `<html><input value="lisp"></html>`)
+
+	p := g.page.MustNavigate(s.URL())
+	el := p.MustElement("input").MustFocus()
+
+	el.MustKeyActions().Press(input.ControlLeft).Type('a').Release(input.ControlLeft).MustDo()
+	g.Eq(el.MustEval(`() => this.value.slice(this.selectionStart, this.selectionEnd)`).Str(), "lisp")
+
+	p.Keyboard.MustType(input.Backspace)
+	g.Eq(el.MustText(), "")
+}
+
 func TestKeyType(t *testing.T) {
 	g := setup(t)
 
@@ -53,6 +70,19 @@ func TestKeyType(t *testing.T) {
 	g.Eq("1 A b test", el.MustText())
 }
 
+func TestKeyTypeUnicode(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/input.html"))
+	el := p.MustElement("[type=text]")
+
+	el.MustType('a', 'é', '😀')
+	g.Eq("aé😀", el.MustText())
+
+	g.mc.stubErr(1, proto.InputDispatchKeyEvent{})
+	g.Err(p.Keyboard.Type('é'))
+}
+
 func TestKeyTypeErr(t *testing.T) {
 	g := setup(t)
 
@@ -169,6 +199,23 @@ func TestMouseClick(t *testing.T) {
 	g.True(page.MustHas("[a=ok]"))
 }
 
+func TestSlowMotionOnlyInput(t *testing.T) {
+	g := setup(t)
+
+	g.browser.SlowMotion(time.Second)
+	defer func() { g.browser.SlowMotion(0) }()
+
+	page := g.page.MustNavigate(g.blank())
+
+	start := time.Now()
+	page.MustEval(`() => 1`)
+	g.Lt(time.Since(start), time.Second)
+
+	start = time.Now()
+	page.Mouse.MustMoveTo(1, 1)
+	g.Gte(time.Since(start), time.Second)
+}
+
 func TestMouseDoubleClick(t *testing.T) {
 	g := setup(t)
 
@@ -196,6 +243,28 @@ func TestMouseDrag(t *testing.T) {
 	g.Eq(page.MustEval(`() => dragTrack`).Str(), " move 3 3 down 3 3 move 22 28 move 41 54 move 60 80 up 60 80")
 }
 
+func TestMouseDragSteps(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage().MustNavigate(g.srcFile("fixtures/drag.html")).MustWaitLoad()
+	mouse := page.Mouse
+
+	g.E(mouse.Drag(proto.NewPoint(3, 3), proto.NewPoint(60, 80), 3))
+
+	utils.Sleep(0.3)
+	g.Eq(page.MustEval(`() => dragTrack`).Str(), " move 3 3 down 3 3 move 22 28 move 41 54 move 60 80 up 60 80")
+
+	mouse.MustDrag(proto.NewPoint(1, 1), proto.NewPoint(2, 2))
+	g.E(mouse.Drag(proto.NewPoint(2, 2), proto.NewPoint(3, 3), 0))
+
+	g.mc.stubErr(1, proto.InputDispatchMouseEvent{})
+	g.Err(mouse.Drag(proto.NewPoint(1, 1), proto.NewPoint(2, 2), 1))
+	g.mc.stubErr(2, proto.InputDispatchMouseEvent{})
+	g.Err(mouse.Drag(proto.NewPoint(1, 1), proto.NewPoint(2, 2), 1))
+	g.mc.stubErr(3, proto.InputDispatchMouseEvent{})
+	g.Err(mouse.Drag(proto.NewPoint(1, 1), proto.NewPoint(2, 2), 1))
+}
+
 func TestMouseScroll(t *testing.T) {
 	g := setup(t)
 
@@ -211,6 +280,37 @@ func TestMouseScroll(t *testing.T) {
 	p.MustWait(`() => pageXOffset > 200 && pageYOffset > 300`)
 }
 
+func TestMouseScrollWheelEvent(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank())
+	p.MustEval(`() => {
+		window.wheelTrack = 0
+		window.addEventListener('wheel', e => window.wheelTrack += e.deltaY)
+	}`)
+
+	p.Mouse.MustMoveTo(10, 10)
+	g.E(p.Mouse.Scroll(0, 30, 3))
+
+	p.MustWait(`() => wheelTrack === 30`)
+}
+
+func TestPageScrollTo(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/scroll.html")).MustWaitLoad()
+
+	p.MustScrollTo(100, 200)
+	g.Eq(100, p.MustEval(`() => pageXOffset`).Int())
+	g.Eq(200, p.MustEval(`() => pageYOffset`).Int())
+
+	p.MustScrollTo(0, p.MustEval(`() => document.body.scrollHeight`).Num())
+	g.True(p.MustEval(`() => innerHeight + pageYOffset >= document.body.scrollHeight`).Bool())
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.ScrollTo(0, 0))
+}
+
 func TestMouseMoveLinear(t *testing.T) {
 	g := setup(t)
 
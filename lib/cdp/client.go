@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/go-rod/rod/lib/defaults"
 	"github.com/go-rod/rod/lib/utils"
@@ -51,6 +52,11 @@ type Client struct {
 	pending sync.Map    // pending requests
 	event   chan *Event // events from browser
 
+	closed   chan struct{} // closed when the connection is closed
+	closeErr error
+
+	keepalive time.Duration
+
 	logger utils.Logger
 }
 
@@ -58,26 +64,59 @@ type Client struct {
 func New() *Client {
 	return &Client{
 		event:  make(chan *Event),
+		closed: make(chan struct{}),
 		logger: defaults.CDP,
 	}
 }
 
 // Logger sets the logger to log all the requests, responses, and events transferred between Rod and the browser.
 // The default format for each type is in file format.go
+// Each Println call receives one value of *Request, *Response, or *Event, the Response.ID is the same
+// as the ID of its Request. The payloads are not formatted until the String method of the value is called,
+// so a custom logger can type switch the value to truncate the large ones, such as the Response.Result.
 func (cdp *Client) Logger(l utils.Logger) *Client {
 	cdp.logger = l
 	return cdp
 }
 
+// Keepalive sends a cheap request to the browser for every interval to prevent the idle connection
+// from being dropped, such as by a load balancer between Rod and a remote browser.
+// It should be called before Client.Start, the default is 0 which means disabled.
+func (cdp *Client) Keepalive(interval time.Duration) *Client {
+	cdp.keepalive = interval
+	return cdp
+}
+
 // Start to browser
 func (cdp *Client) Start(ws WebSocketable) *Client {
 	cdp.ws = ws
 
 	go cdp.consumeMessages()
 
+	if cdp.keepalive > 0 {
+		go cdp.keepConnAlive()
+	}
+
 	return cdp
 }
 
+func (cdp *Client) keepConnAlive() {
+	t := time.NewTicker(cdp.keepalive)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-cdp.closed:
+			return
+		case <-t.C:
+			// a half-open connection may never respond, so don't let one ping block the next one
+			ctx, cancel := context.WithTimeout(context.Background(), cdp.keepalive)
+			_, _ = cdp.Call(ctx, "", "Browser.getVersion", nil)
+			cancel()
+		}
+	}
+}
+
 type result struct {
 	msg json.RawMessage
 	err error
@@ -111,7 +150,7 @@ func (cdp *Client) Call(ctx context.Context, sessionID, method string, params in
 
 	err = cdp.ws.Send(data)
 	if err != nil {
-		return nil, err
+		return nil, &ErrConnectionClosed{err}
 	}
 
 	select {
@@ -119,6 +158,8 @@ func (cdp *Client) Call(ctx context.Context, sessionID, method string, params in
 		return nil, ctx.Err()
 	case res := <-done:
 		return res.msg, res.err
+	case <-cdp.closed:
+		return nil, cdp.closeErr
 	}
 }
 
@@ -134,10 +175,12 @@ func (cdp *Client) consumeMessages() {
 	for {
 		data, err := cdp.ws.Read()
 		if err != nil {
+			cdp.closeErr = &ErrConnectionClosed{err}
 			cdp.pending.Range(func(_, val interface{}) bool {
-				val.(func(result))(result{err: err})
+				val.(func(result))(result{err: cdp.closeErr})
 				return true
 			})
+			close(cdp.closed)
 			return
 		}
 
@@ -3,6 +3,7 @@ package cdp_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -130,6 +131,40 @@ func TestError(t *testing.T) {
 	})
 }
 
+func TestCallErr(t *testing.T) {
+	g := setup(t)
+
+	id := 0
+	wait := make(chan struct{})
+	ws := &MockWebSocket{
+		send: func([]byte) error {
+			close(wait)
+			return nil
+		},
+		read: func() ([]byte, error) {
+			if id > 0 {
+				return nil, io.EOF
+			}
+
+			id++
+			<-wait
+
+			return json.Marshal(cdp.Response{
+				ID:    1,
+				Error: &cdp.Error{Code: -32601, Message: "'a' wasn't found"},
+			})
+		},
+	}
+
+	c := cdp.New().Start(ws)
+	_, err := c.Call(g.Context(), "", "a", nil)
+
+	var e *cdp.Error
+	g.True(errors.As(err, &e))
+	g.Eq(e.Code, -32601)
+	g.Has(err.Error(), "'a' wasn't found")
+}
+
 func TestCrash(t *testing.T) {
 	g := setup(t)
 
@@ -166,18 +201,20 @@ func TestCrash(t *testing.T) {
 	go func() {
 		utils.Sleep(1)
 		_, err := client.Call(ctx, sessionID, "Browser.crash", nil)
-		g.Eq(err, io.EOF)
+		g.Is(err, io.EOF)
 	}()
 
 	_, err = client.Call(ctx, sessionID, "Runtime.evaluate", map[string]interface{}{
 		"expression":   `new Promise(() => {})`,
 		"awaitPromise": true,
 	})
-	g.Eq(err, io.EOF)
+	g.Is(err, io.EOF)
+	g.Is(err, &cdp.ErrConnectionClosed{})
 
 	_, err = client.Call(ctx, sessionID, "Runtime.evaluate", map[string]interface{}{
 		"expression": `10`,
 	})
+	g.Is(err, &cdp.ErrConnectionClosed{})
 	g.Has(err.Error(), "use of closed network connection")
 }
 
@@ -341,6 +378,99 @@ func TestMassBrowserClose(t *testing.T) {
 	}
 }
 
+func TestCallAfterConnectionClosed(t *testing.T) {
+	g := setup(t)
+
+	closed := make(chan struct{})
+	ws := &MockWebSocket{
+		send: func([]byte) error { return nil },
+		read: func() ([]byte, error) {
+			defer close(closed)
+			return nil, io.EOF
+		},
+	}
+
+	c := cdp.New().Start(ws)
+	<-closed
+
+	// the send succeeds but the response will never come
+	_, err := c.Call(g.Context(), "", "method", nil)
+	g.Is(err, &cdp.ErrConnectionClosed{})
+	g.Is(err, io.EOF)
+	g.Eq(err.Error(), "cdp connection closed: EOF")
+}
+
+func TestKeepalive(t *testing.T) {
+	g := setup(t)
+
+	gotrace.CheckLeak(g, 0)
+
+	sent := make(chan string, 10)
+	replies := make(chan []byte, 10)
+	closed := make(chan struct{})
+	ws := &MockWebSocket{
+		send: func(data []byte) error {
+			req := gson.New(data)
+			select {
+			case sent <- req.Get("method").Str():
+			default:
+			}
+			select {
+			case replies <- []byte(fmt.Sprintf(`{"id":%d,"result":{}}`, req.Get("id").Int())):
+			default:
+			}
+			return nil
+		},
+		read: func() ([]byte, error) {
+			select {
+			case <-closed:
+				return nil, io.EOF
+			case data := <-replies:
+				return data, nil
+			}
+		},
+	}
+
+	cdp.New().Keepalive(time.Millisecond).Start(ws)
+
+	for i := 0; i < 3; i++ {
+		g.Eq(<-sent, "Browser.getVersion")
+	}
+
+	close(closed)
+}
+
+func TestKeepaliveNoResponse(t *testing.T) {
+	g := setup(t)
+
+	gotrace.CheckLeak(g, 0)
+
+	sent := make(chan string, 10)
+	closed := make(chan struct{})
+	ws := &MockWebSocket{
+		send: func(data []byte) error {
+			select {
+			case sent <- gson.New(data).Get("method").Str():
+			default:
+			}
+			return nil
+		},
+		read: func() ([]byte, error) {
+			<-closed
+			return nil, io.EOF
+		},
+	}
+
+	// the browser never responds, the pings should keep going
+	cdp.New().Keepalive(time.Millisecond).Start(ws)
+
+	for i := 0; i < 3; i++ {
+		g.Eq(<-sent, "Browser.getVersion")
+	}
+
+	close(closed)
+}
+
 type MockWebSocket struct {
 	send func(data []byte) error
 	read func() ([]byte, error)
@@ -4,7 +4,13 @@ import (
 	"fmt"
 )
 
-// Error of the Response
+// Error of the Response. Client.Call returns it when the browser responds with an error,
+// use errors.As to get it and branch on the Code, such as:
+//
+//	var e *cdp.Error
+//	if errors.As(err, &e) && e.Code == -32601 {
+//	    // method not found
+//	}
 type Error struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
@@ -22,6 +28,30 @@ func (e Error) Is(target error) bool {
 	return ok && e == *err
 }
 
+// ErrConnectionClosed is returned by Client.Call when the websocket connection to the browser is closed,
+// such as when the browser crashed or the network is down. The in-flight calls will get it too.
+// The client doesn't reconnect automatically, it can't be reused after it, you have to create a new client
+// to reconnect to the browser, check the "reconnect" example of rod for how to rebuild the browser.
+type ErrConnectionClosed struct {
+	Err error
+}
+
+// Error stdlib interface
+func (e *ErrConnectionClosed) Error() string {
+	return fmt.Sprintf("cdp connection closed: %v", e.Err)
+}
+
+// Unwrap stdlib interface
+func (e *ErrConnectionClosed) Unwrap() error {
+	return e.Err
+}
+
+// Is stdlib interface
+func (e *ErrConnectionClosed) Is(target error) bool {
+	_, ok := target.(*ErrConnectionClosed)
+	return ok
+}
+
 // ErrCtxNotFound type
 var ErrCtxNotFound = &Error{
 	Code:    -32000,
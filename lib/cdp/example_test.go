@@ -49,7 +49,16 @@ func Example_customize_cdp_log() {
 		Logger(utils.Log(func(args ...interface{}) {
 			switch v := args[0].(type) {
 			case *cdp.Request:
-				fmt.Printf("id: %d", v.ID)
+				fmt.Printf("=> id: %d %s\n", v.ID, v.Method)
+			case *cdp.Response:
+				// truncate the large payload, such as the data of a screenshot
+				payload := v.Result
+				if len(payload) > 100 {
+					payload = payload[:100]
+				}
+				fmt.Printf("<= id: %d %s\n", v.ID, payload)
+			case *cdp.Event:
+				fmt.Printf("<- %s\n", v.Method)
 			}
 		})).
 		Start(ws)
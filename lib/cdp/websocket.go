@@ -47,7 +47,29 @@ func (ws *WebSocket) Connect(ctx context.Context, wsURL string, header http.Head
 
 	ws.conn = conn
 	ws.r = bufio.NewReader(conn)
-	return ws.handshake(ctx, u, header)
+
+	// The conn doesn't respect the ctx, close it to abort the handshake when the ctx is done,
+	// such as when the remote endpoint accepts the connection but never responds.
+	aborted := false
+	stop := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case <-ctx.Done():
+			aborted = true
+			_ = conn.Close()
+		case <-stop:
+		}
+	}()
+
+	err = ws.handshake(ctx, u, header)
+	close(stop)
+	<-exited
+	if aborted {
+		return ctx.Err()
+	}
+	return err
 }
 
 // Close the underlying connection.
@@ -3,11 +3,13 @@ package cdp_test
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/launcher"
@@ -73,6 +75,28 @@ func TestWebSocketHeader(t *testing.T) {
 	g.Eq(err.Error(), "websocket bad handshake: 200 OK. ")
 }
 
+func TestWebSocketHandshakeTimeout(t *testing.T) {
+	g := setup(t)
+
+	// a dead endpoint that accepts the connection but never responds
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	g.E(err)
+	defer func() { _ = l.Close() }()
+	go func() {
+		conn, err := l.Accept()
+		if err == nil {
+			<-g.Context().Done()
+			_ = conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(g.Context(), 100*time.Millisecond)
+	defer cancel()
+
+	ws := cdp.WebSocket{}
+	g.Is(ws.Connect(ctx, "ws://"+l.Addr().String(), nil), context.DeadlineExceeded)
+}
+
 func newPage(ctx context.Context, g got.G) (*cdp.Client, string) {
 	l := launcher.New()
 	g.Cleanup(l.Kill)
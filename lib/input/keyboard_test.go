@@ -65,6 +65,9 @@ func TestKeyMap(t *testing.T) {
 	g.Panic(func() {
 		input.Key('\n').Info()
 	})
+
+	g.True(input.Key('!').Defined())
+	g.False(input.Key('é').Defined())
 }
 
 func TestKeyModifier(t *testing.T) {
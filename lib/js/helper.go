@@ -114,6 +114,13 @@ var WaitLoad = &Function{
 	Dependencies: []*Function{},
 }
 
+// WaitDOMStable ...
+var WaitDOMStable = &Function{
+	Name:         "waitDOMStable",
+	Definition:   `function(t){return new Promise(e=>{let n;const o=new MutationObserver(()=>{clearTimeout(n),n=setTimeout(i,t)}),i=()=>{o.disconnect(),e()};o.observe(document,{attributes:!0,childList:!0,subtree:!0,characterData:!0}),n=setTimeout(i,t)})}`,
+	Dependencies: []*Function{},
+}
+
 // InputEvent ...
 var InputEvent = &Function{
 	Name:         "inputEvent",
@@ -145,14 +152,14 @@ var SelectAllText = &Function{
 // Select ...
 var Select = &Function{
 	Name:         "select",
-	Definition:   `function(e,n,t){let i;switch(t){case"regex":i=e.map(e=>{const t=new RegExp(e);return e=>t.test(e.innerText)});break;case"css-selector":i=e.map(t=>e=>e.matches(t));break;default:i=e.map(t=>e=>e.innerText.includes(t))}const r=Array.from(this.options);let o=!1;return i.forEach(e=>{const t=r.find(e);t&&(t.selected=n,o=!0)}),this.dispatchEvent(new Event("input",{bubbles:!0})),this.dispatchEvent(new Event("change",{bubbles:!0})),o}`,
+	Definition:   `function(e,n,t){let i;switch(t){case"regex":i=e.map(e=>{const t=new RegExp(e);return e=>t.test(e.innerText)});break;case"css-selector":i=e.map(t=>e=>e.matches(t));break;default:i=e.map(t=>e=>e.innerText.includes(t))}const r=Array.from(this.options),o=[];return i.forEach((t,i)=>{const s=r.find(t);s?s.selected=n:o.push(e[i])}),this.dispatchEvent(new Event("input",{bubbles:!0})),this.dispatchEvent(new Event("change",{bubbles:!0})),o}`,
 	Dependencies: []*Function{},
 }
 
 // Visible ...
 var Visible = &Function{
 	Name:         "visible",
-	Definition:   `function(){const e=functions.tag(this);var t=e.getBoundingClientRect(),n=window.getComputedStyle(e);return"none"!==n.display&&"hidden"!==n.visibility&&!!(t.top||t.bottom||t.width||t.height)}`,
+	Definition:   `function(){const e=functions.tag(this);var t=e.getBoundingClientRect(),n=window.getComputedStyle(e);for(let o=e;o;o=o.parentElement)if("0"===window.getComputedStyle(o).opacity)return!1;return"none"!==n.display&&"hidden"!==n.visibility&&!!(t.top||t.bottom||t.width||t.height)}`,
 	Dependencies: []*Function{Tag},
 }
 
@@ -163,6 +170,13 @@ var Invisible = &Function{
 	Dependencies: []*Function{Visible},
 }
 
+// InvisibleElements ...
+var InvisibleElements = &Function{
+	Name:         "invisibleElements",
+	Definition:   `function(e){return Array.from(functions.selectable(this).querySelectorAll(e)).every(e=>functions.invisible.apply(e))}`,
+	Dependencies: []*Function{Selectable, Invisible},
+}
+
 // Text ...
 var Text = &Function{
 	Name:         "text",
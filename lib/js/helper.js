@@ -211,6 +211,27 @@ const functions = {
     })
   },
 
+  waitDOMStable(quiet) {
+    return new Promise((resolve) => {
+      let timer
+      const observer = new MutationObserver(() => {
+        clearTimeout(timer)
+        timer = setTimeout(done, quiet)
+      })
+      const done = () => {
+        observer.disconnect()
+        resolve()
+      }
+      observer.observe(document, {
+        attributes: true,
+        childList: true,
+        subtree: true,
+        characterData: true
+      })
+      timer = setTimeout(done, quiet)
+    })
+  },
+
   inputEvent() {
     this.dispatchEvent(new Event('input', { bubbles: true }))
     this.dispatchEvent(new Event('change', { bubbles: true }))
@@ -274,26 +295,32 @@ const functions = {
     }
 
     const opts = Array.from(this.options)
-    let has = false
-    matchers.forEach((s) => {
+    const notFound = []
+    matchers.forEach((s, i) => {
       const el = opts.find(s)
       if (el) {
         el.selected = selected
-        has = true
         return
       }
+      notFound.push(selectors[i])
     })
 
     this.dispatchEvent(new Event('input', { bubbles: true }))
     this.dispatchEvent(new Event('change', { bubbles: true }))
 
-    return has
+    return notFound
   },
 
   visible() {
     const el = functions.tag(this)
     const box = el.getBoundingClientRect()
     const style = window.getComputedStyle(el)
+
+    // opacity isn't inherited, the computed one of the element may not be zero when a parent is transparent
+    for (let p = el; p; p = p.parentElement) {
+      if (window.getComputedStyle(p).opacity === '0') return false
+    }
+
     return (
       style.display !== 'none' &&
       style.visibility !== 'hidden' &&
@@ -305,6 +332,12 @@ const functions = {
     return !functions.visible.apply(this)
   },
 
+  invisibleElements(selector) {
+    return Array.from(
+      functions.selectable(this).querySelectorAll(selector)
+    ).every((el) => functions.invisible.apply(el))
+  },
+
   text() {
     switch (this.tagName) {
       case 'INPUT':
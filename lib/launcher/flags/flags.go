@@ -25,6 +25,12 @@ const (
 	// ProxyServer flag
 	ProxyServer Flag = "proxy-server"
 
+	// WindowSize flag, such as "1280,800"
+	WindowSize Flag = "window-size"
+
+	// WindowPosition flag, such as "0,0"
+	WindowPosition Flag = "window-position"
+
 	// WorkingDir flag
 	WorkingDir Flag = "rod-working-dir"
 
@@ -22,6 +22,12 @@ import (
 // DefaultUserDataDirPrefix ...
 var DefaultUserDataDirPrefix = filepath.Join(os.TempDir(), "rod", "user-data")
 
+// DefaultWindowWidth and DefaultWindowHeight are the window size for headful mode if it's not specified
+var (
+	DefaultWindowWidth  = 1280
+	DefaultWindowHeight = 800
+)
+
 // Launcher is a helper to launch browser binary smartly
 type Launcher struct {
 	Flags map[flags.Flag][]string `json:"flags"`
@@ -92,6 +98,7 @@ func New() *Launcher {
 
 	if defaults.Show {
 		delete(defaultFlags, flags.Headless)
+		defaultFlags[flags.WindowSize] = []string{fmt.Sprintf("%d,%d", DefaultWindowWidth, DefaultWindowHeight)}
 	}
 	if defaults.Devtools {
 		defaultFlags["auto-open-devtools-for-tabs"] = nil
@@ -157,7 +164,13 @@ func (l *Launcher) Context(ctx context.Context) *Launcher {
 	return l
 }
 
-// Set a command line argument to launch the browser.
+// Set a command line argument to launch the browser, it overrides the existing values of the flag.
+// Use it for the flags that don't have a builder, the leading dashes of the name are optional, such as:
+//
+//	Set("disable-gpu").Set("--lang", "de-DE")
+//
+// Multiple values will be joined with ",".
+// The full list of flags: https://peter.sh/experiments/chromium-command-line-switches
 func (l *Launcher) Set(name flags.Flag, values ...string) *Launcher {
 	if strings.Contains(string(name), "=") {
 		panic("flag name should not contain '='")
@@ -195,13 +208,17 @@ func (l *Launcher) Append(name flags.Flag, values ...string) *Launcher {
 	return l.Set(name, append(flags, values...)...)
 }
 
-// Delete a flag
+// Delete a flag. It can also remove the flags that are set by default, check the source code of New
+// for the list, such as:
+//
+//	Delete("disable-features").Delete(flags.Headless)
 func (l *Launcher) Delete(name flags.Flag) *Launcher {
 	delete(l.Flags, l.normalizeFlag(name))
 	return l
 }
 
-// Bin of the browser binary path to launch, if the path is not empty the auto download will be disabled
+// Bin of the browser binary path to launch, if the path is not empty the auto download will be disabled.
+// If the path doesn't exist or isn't executable, Launcher.Launch will return an error.
 func (l *Launcher) Bin(path string) *Launcher {
 	return l.Set(flags.Bin, path)
 }
@@ -213,13 +230,30 @@ func (l *Launcher) Revision(rev int) *Launcher {
 }
 
 // Headless switch. Whether to run browser in headless mode. A mode without visible UI.
+// When disabled, the window size will be DefaultWindowWidth x DefaultWindowHeight if it's not set yet,
+// use Launcher.WindowSize to override it.
 func (l *Launcher) Headless(enable bool) *Launcher {
 	if enable {
 		return l.Set(flags.Headless)
 	}
+	if !l.Has(flags.WindowSize) {
+		l.WindowSize(DefaultWindowWidth, DefaultWindowHeight)
+	}
 	return l.Delete(flags.Headless)
 }
 
+// WindowSize of the browser window in pixels. It's useful for headful mode, the page viewport
+// is still controlled by the device emulation of the page.
+func (l *Launcher) WindowSize(width, height int) *Launcher {
+	return l.Set(flags.WindowSize, fmt.Sprintf("%d,%d", width, height))
+}
+
+// WindowPosition of the browser window's top-left corner on the screen in pixels.
+// Useful to choose which monitor to open the headful browser on.
+func (l *Launcher) WindowPosition(x, y int) *Launcher {
+	return l.Set(flags.WindowPosition, fmt.Sprintf("%d,%d", x, y))
+}
+
 // NoSandbox switch. Whether to run browser in no-sandbox mode.
 // Linux users may face "running as root without --no-sandbox is not supported" in some Linux/Chrome combinations. This function helps switch mode easily.
 // Be aware disabling sandbox is not trivial. Use at your own risk.
@@ -238,6 +272,9 @@ func (l *Launcher) XVFB(args ...string) *Launcher {
 
 // Leakless switch. If enabled, the browser will be force killed after the Go process exits.
 // The doc of leakless: https://github.com/ysmood/leakless.
+// If disabled, the browser will be launched directly without the leakless helper process, which is useful
+// when the environment forbids the helper binary. But the browser may keep running after the Go process exits,
+// so you should use Browser.Close or Launcher.Kill to clean it up.
 func (l *Launcher) Leakless(enable bool) *Launcher {
 	if enable {
 		return l.Set(flags.Leakless)
@@ -255,12 +292,15 @@ func (l *Launcher) Devtools(autoOpenForTabs bool) *Launcher {
 
 // UserDataDir is where the browser will look for all of its state, such as cookie and cache.
 // When set to empty, browser will use current OS home dir.
+// The dir will be created if it doesn't exist, and unlike the default one, it won't be removed by Launcher.Cleanup .
 // Related doc: https://chromium.googlesource.com/chromium/src/+/master/docs/user_data_dir.md
 func (l *Launcher) UserDataDir(dir string) *Launcher {
 	if dir == "" {
 		l.Delete(flags.UserDataDir)
+		l.Delete(flags.KeepUserDataDir)
 	} else {
 		l.Set(flags.UserDataDir, dir)
+		l.Set(flags.KeepUserDataDir)
 	}
 	return l
 }
@@ -280,11 +320,16 @@ func (l *Launcher) ProfileDir(dir string) *Launcher {
 // RemoteDebuggingPort to launch the browser. Zero for a random port. Zero is the default value.
 // If it's not zero and the Launcher.Leakless is disabled, the launcher will try to reconnect to it first,
 // if the reconnection fails it will launch a new browser.
+// Launcher.Launch returns the websocket url on the port. The browser only listens on the loopback interface,
+// to accept connections from other hosts, such as other containers, use:
+//
+//	Set("remote-debugging-address", "0.0.0.0")
 func (l *Launcher) RemoteDebuggingPort(port int) *Launcher {
 	return l.Set(flags.RemoteDebuggingPort, fmt.Sprintf("%d", port))
 }
 
-// Proxy switch. When disabled leakless will be disabled.
+// Proxy for the browser, such as "127.0.0.1:8080" or "socks5://127.0.0.1:1080".
+// If the proxy requires authentication, use rod.Browser.HandleAuth to supply the credentials.
 func (l *Launcher) Proxy(host string) *Launcher {
 	return l.Set(flags.ProxyServer, host)
 }
@@ -298,10 +343,24 @@ func (l *Launcher) WorkingDir(path string) *Launcher {
 // Usually you use it to set the timezone env. Such as:
 //
 //	Env(append(os.Environ(), "TZ=Asia/Tokyo")...)
+//
+// It replaces the whole environment, use Launcher.MergeEnv to only add or override some keys.
 func (l *Launcher) Env(env ...string) *Launcher {
 	return l.Set(flags.Env, env...)
 }
 
+// MergeEnv appends the "key=value" pairs to the environment of the browser process, the later value wins
+// if a key is duplicated. If Launcher.Env isn't set, the pairs are merged into os.Environ(). Such as:
+//
+//	MergeEnv("TZ=Asia/Tokyo", "DISPLAY=:99")
+func (l *Launcher) MergeEnv(env ...string) *Launcher {
+	list, has := l.GetFlags(flags.Env)
+	if !has {
+		list = os.Environ()
+	}
+	return l.Set(flags.Env, append(append([]string{}, list...), env...)...)
+}
+
 // StartURL to launch
 func (l *Launcher) StartURL(u string) *Launcher {
 	return l.Set("", u)
@@ -378,6 +437,13 @@ func (l *Launcher) Launch() (string, error) {
 		cmd = exec.Command(bin, l.FormatArgs()...)
 	}
 
+	if dir := l.Get(flags.UserDataDir); dir != "" {
+		err = utils.Mkdir(dir)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	l.setupCmd(cmd)
 
 	err = cmd.Start()
@@ -426,7 +492,12 @@ func (l *Launcher) getBin() (string, error) {
 		l.browser.Context = l.ctx
 		return l.browser.Get()
 	}
-	return bin, nil
+
+	p, err := exec.LookPath(bin)
+	if err != nil {
+		return "", fmt.Errorf("invalid browser binary: %w", err)
+	}
+	return p, nil
 }
 
 func (l *Launcher) getURL() (u string, err error) {
@@ -462,10 +533,15 @@ func (l *Launcher) Kill() {
 	}
 }
 
-// Cleanup wait until the Browser exits and remove UserDataDir
+// Cleanup wait until the Browser exits and remove UserDataDir.
+// The dir set by Launcher.UserDataDir will be kept.
 func (l *Launcher) Cleanup() {
 	<-l.exit
 
+	if l.Has(flags.KeepUserDataDir) {
+		return
+	}
+
 	dir := l.Get(flags.UserDataDir)
 	_ = os.RemoveAll(dir)
 }
@@ -4,15 +4,18 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -128,6 +131,56 @@ func TestLaunch(t *testing.T) {
 	}
 }
 
+func TestLaunchRemoteDebuggingPort(t *testing.T) {
+	g := setup(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	g.E(err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	g.E(ln.Close())
+
+	l := launcher.New().RemoteDebuggingPort(port)
+	defer l.Kill()
+
+	u, err := url.Parse(l.MustLaunch())
+	g.E(err)
+	g.Eq(u.Port(), fmt.Sprintf("%d", port))
+}
+
+func TestLaunchWithoutLeakless(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New().Leakless(false)
+	defer l.Kill()
+
+	g.Regex(`\Aws://.+\z`, l.MustLaunch())
+	g.Gt(l.PID(), 0)
+}
+
+func TestUserDataDirKept(t *testing.T) {
+	g := setup(t)
+
+	dir := filepath.Join(os.TempDir(), "rod", "test-user-data", g.RandStr(8))
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	l := launcher.New().UserDataDir(dir)
+	l.MustLaunch()
+	l.Kill()
+	l.Cleanup()
+
+	_, err := os.Stat(dir)
+	g.E(err)
+
+	l = launcher.New()
+	dir = l.Get(flags.UserDataDir)
+	l.MustLaunch()
+	l.Kill()
+	l.Cleanup()
+
+	_, err = os.Stat(dir)
+	g.True(os.IsNotExist(err))
+}
+
 func TestLaunchUserMode(t *testing.T) {
 	g := setup(t)
 
@@ -183,6 +236,47 @@ func TestUserModeErr(t *testing.T) {
 	g.Err(err)
 }
 
+func TestProxy(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New().Proxy("socks5://127.0.0.1:1080")
+
+	g.Eq("socks5://127.0.0.1:1080", l.Get(flags.ProxyServer))
+}
+
+func TestSetDeleteFlags(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New().
+		Set("disable-gpu").
+		Set("--lang", "de-DE").
+		Set("disable-features", "a", "b").
+		Delete("--enable-automation")
+
+	args := strings.Join(l.FormatArgs(), " ")
+	g.Has(args, "--disable-gpu")
+	g.Has(args, "--lang=de-DE")
+	g.Has(args, "--disable-features=a,b")
+	g.False(l.Has("enable-automation"))
+	g.Eq("de-DE", l.Get("lang"))
+}
+
+func TestWindowSize(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New().Headless(false)
+	g.Eq("1280,800", l.Get(flags.WindowSize))
+	g.False(l.Has(flags.Headless))
+
+	l = launcher.New().WindowSize(800, 600).Headless(false).WindowPosition(-1920, 0)
+	g.Eq("800,600", l.Get(flags.WindowSize))
+	g.Eq("-1920,0", l.Get(flags.WindowPosition))
+	g.Has(strings.Join(l.FormatArgs(), " "), "--window-position=-1920,0 --window-size=800,600")
+
+	l = launcher.New().Headless(true)
+	g.False(l.Has(flags.WindowSize))
+}
+
 func TestAppMode(t *testing.T) {
 	g := setup(t)
 
@@ -204,12 +298,20 @@ func TestLaunchErr(t *testing.T) {
 	g.Panic(func() {
 		launcher.New().Bin("not-exists").MustLaunch()
 	})
+	{
+		_, err := launcher.New().Bin("not-exists").Launch()
+		g.Has(err.Error(), "invalid browser binary")
+		g.True(errors.Is(err, exec.ErrNotFound))
+	}
 	g.Panic(func() {
 		launcher.New().Headless(false).Bin("not-exists").MustLaunch()
 	})
 	g.Panic(func() {
 		launcher.New().ClientHeader()
 	})
+	g.Panic(func() {
+		launcher.New().UserDataDir("launcher_test.go/dir").MustLaunch()
+	})
 	{
 		l := launcher.New().XVFB()
 		_, _ = l.Launch()
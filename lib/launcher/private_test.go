@@ -85,6 +85,28 @@ func TestLaunchOptions(t *testing.T) {
 	g.True(l.Has("auto-open-devtools-for-tabs"))
 }
 
+func TestLaunchEnv(t *testing.T) {
+	g := setup(t)
+
+	cmd := exec.Command("")
+	New().setupCmd(cmd)
+	g.Len(cmd.Env, 0)
+
+	// Env replaces the whole environment
+	cmd = exec.Command("")
+	New().Env("A=1").Env("B=2", "A=3").setupCmd(cmd)
+	g.Eq(cmd.Env, []string{"B=2", "A=3"})
+
+	cmd = exec.Command("")
+	New().MergeEnv("A=1").MergeEnv("B=2", "A=3").setupCmd(cmd)
+	g.Eq(cmd.Env[:len(cmd.Env)-3], os.Environ())
+	g.Eq(cmd.Env[len(cmd.Env)-3:], []string{"A=1", "B=2", "A=3"})
+
+	cmd = exec.Command("")
+	New().Env("A=1").MergeEnv("B=2").setupCmd(cmd)
+	g.Eq(cmd.Env, []string{"A=1", "B=2"})
+}
+
 func TestGetURLErr(t *testing.T) {
 	g := setup(t)
 
@@ -98,6 +98,18 @@ func (b *Browser) MustIgnoreCertErrors(enable bool) *Browser {
 	return b
 }
 
+// MustGrantPermissions is similar to Browser.GrantPermissions
+func (b *Browser) MustGrantPermissions(origin string, permissions ...proto.BrowserPermissionType) *Browser {
+	b.e(b.GrantPermissions(origin, permissions...))
+	return b
+}
+
+// MustResetPermissions is similar to Browser.ResetPermissions
+func (b *Browser) MustResetPermissions() *Browser {
+	b.e(b.ResetPermissions())
+	return b
+}
+
 // MustGetCookies is similar Browser.GetCookies
 func (b *Browser) MustGetCookies() []*proto.NetworkCookie {
 	nc, err := b.GetCookies()
@@ -116,6 +128,37 @@ func (b *Browser) MustSetCookies(cookies ...*proto.NetworkCookie) *Browser {
 	return b
 }
 
+// MustClearCache is similar to Browser.ClearCache
+func (b *Browser) MustClearCache() *Browser {
+	b.e(b.ClearCache())
+	return b
+}
+
+// MustServiceWorkers is similar to Browser.ServiceWorkers
+func (b *Browser) MustServiceWorkers() []*proto.TargetTargetInfo {
+	list, err := b.ServiceWorkers()
+	b.e(err)
+	return list
+}
+
+// MustUnregisterServiceWorkers is similar to Browser.UnregisterServiceWorkers
+func (b *Browser) MustUnregisterServiceWorkers(origin string) *Browser {
+	b.e(b.UnregisterServiceWorkers(origin))
+	return b
+}
+
+// MustStopAllServiceWorkers is similar to Browser.StopAllServiceWorkers
+func (b *Browser) MustStopAllServiceWorkers() *Browser {
+	b.e(b.StopAllServiceWorkers())
+	return b
+}
+
+// MustSetDownloadPath is similar to Browser.SetDownloadPath
+func (b *Browser) MustSetDownloadPath(dir string) *Browser {
+	b.e(b.SetDownloadPath(dir))
+	return b
+}
+
 // MustWaitDownload is similar to Browser.WaitDownload.
 // It will read the file into bytes then remove the file.
 func (b *Browser) MustWaitDownload() func() []byte {
@@ -139,6 +182,16 @@ func (b *Browser) MustVersion() *proto.BrowserGetVersionResult {
 	return v
 }
 
+// MustWaitPage is similar to Browser.WaitPage
+func (b *Browser) MustWaitPage() (wait func() (newPage *Page)) {
+	w := b.WaitPage()
+	return func() *Page {
+		page, err := w()
+		b.e(err)
+		return page
+	}
+}
+
 // MustFind is similar to Browser.Find
 func (ps Pages) MustFind(selector string) *Page {
 	p, err := ps.Find(selector)
@@ -208,6 +261,57 @@ func (p *Page) MustSetCookies(cookies ...*proto.NetworkCookieParam) *Page {
 	return p
 }
 
+// MustLocalStorage is similar to Page.LocalStorage
+func (p *Page) MustLocalStorage() map[string]string {
+	items, err := p.LocalStorage()
+	p.e(err)
+	return items
+}
+
+// MustSetLocalStorage is similar to Page.SetLocalStorage
+func (p *Page) MustSetLocalStorage(key, val string) *Page {
+	p.e(p.SetLocalStorage(key, val))
+	return p
+}
+
+// MustClearLocalStorage is similar to Page.ClearLocalStorage
+func (p *Page) MustClearLocalStorage() *Page {
+	p.e(p.ClearLocalStorage())
+	return p
+}
+
+// MustSessionStorage is similar to Page.SessionStorage
+func (p *Page) MustSessionStorage() map[string]string {
+	items, err := p.SessionStorage()
+	p.e(err)
+	return items
+}
+
+// MustSetSessionStorage is similar to Page.SetSessionStorage
+func (p *Page) MustSetSessionStorage(key, val string) *Page {
+	p.e(p.SetSessionStorage(key, val))
+	return p
+}
+
+// MustClearSessionStorage is similar to Page.ClearSessionStorage
+func (p *Page) MustClearSessionStorage() *Page {
+	p.e(p.ClearSessionStorage())
+	return p
+}
+
+// MustCookieJar is similar to Page.CookieJar
+func (p *Page) MustCookieJar(urls ...string) http.CookieJar {
+	jar, err := p.CookieJar(urls)
+	p.e(err)
+	return jar
+}
+
+// MustSetCookieJar is similar to Page.SetCookieJar
+func (p *Page) MustSetCookieJar(jar http.CookieJar, urls ...string) *Page {
+	p.e(p.SetCookieJar(jar, urls))
+	return p
+}
+
 // MustSetExtraHeaders is similar to Page.SetExtraHeaders
 func (p *Page) MustSetExtraHeaders(dict ...string) (cleanup func()) {
 	cleanup, err := p.SetExtraHeaders(dict)
@@ -233,12 +337,45 @@ func (p *Page) MustReload() *Page {
 	return p
 }
 
+// MustReloadIgnoreCache is similar to Page.ReloadIgnoreCache
+func (p *Page) MustReloadIgnoreCache() *Page {
+	p.e(p.ReloadIgnoreCache())
+	return p
+}
+
+// MustFrames is similar to Page.Frames
+func (p *Page) MustFrames() []*proto.PageFrame {
+	list, err := p.Frames()
+	p.e(err)
+	return list
+}
+
+// MustFrameByID is similar to Page.FrameByID
+func (p *Page) MustFrameByID(id proto.PageFrameID) *Page {
+	frame, err := p.FrameByID(id)
+	p.e(err)
+	return frame
+}
+
 // MustActivate is similar to Page.Activate
 func (p *Page) MustActivate() *Page {
 	p.e(p.Activate())
 	return p
 }
 
+// MustMetrics is similar to Page.Metrics
+func (p *Page) MustMetrics() map[string]float64 {
+	metrics, err := p.Metrics()
+	p.e(err)
+	return metrics
+}
+
+// MustScrollTo is similar to Page.ScrollTo
+func (p *Page) MustScrollTo(x, y float64) *Page {
+	p.e(p.ScrollTo(x, y))
+	return p
+}
+
 // MustNavigateBack is similar to Page.NavigateBack
 func (p *Page) MustNavigateBack() *Page {
 	p.e(p.NavigateBack())
@@ -313,6 +450,28 @@ func (p *Page) MustSetViewport(width, height int, deviceScaleFactor float64, mob
 	return p
 }
 
+// MustSetGeolocation is similar to Page.SetGeolocation, the accuracy will be 1
+func (p *Page) MustSetGeolocation(latitude, longitude float64) *Page {
+	p.e(p.SetGeolocation(&proto.EmulationSetGeolocationOverride{
+		Latitude:  gson.Num(latitude),
+		Longitude: gson.Num(longitude),
+		Accuracy:  gson.Num(1),
+	}))
+	return p
+}
+
+// MustSetTimezone is similar to Page.SetTimezone
+func (p *Page) MustSetTimezone(tz string) *Page {
+	p.e(p.SetTimezone(tz))
+	return p
+}
+
+// MustEmulateMedia is similar to Page.EmulateMedia, it only overrides the media type, such as "print"
+func (p *Page) MustEmulateMedia(media string) *Page {
+	p.e(p.EmulateMedia(&proto.EmulationSetEmulatedMedia{Media: media}))
+	return p
+}
+
 // MustEmulate is similar to Page.Emulate
 func (p *Page) MustEmulate(device devices.Device) *Page {
 	p.e(p.Emulate(device))
@@ -380,6 +539,68 @@ func (p *Page) MustPDF(toFile ...string) []byte {
 	return bin
 }
 
+// MustStartTracing is similar to Page.StartTracing
+func (p *Page) MustStartTracing(categories ...string) (stop func() []byte) {
+	s, err := p.StartTracing(categories)
+	p.e(err)
+	return func() []byte {
+		r, err := s()
+		p.e(err)
+		bin, err := ioutil.ReadAll(r)
+		p.e(err)
+		return bin
+	}
+}
+
+// MustStartJSCoverage is similar to Page.StartJSCoverage
+func (p *Page) MustStartJSCoverage() (stop func() []*JSCoverage) {
+	s, err := p.StartJSCoverage()
+	p.e(err)
+	return func() []*JSCoverage {
+		list, err := s()
+		p.e(err)
+		return list
+	}
+}
+
+// MustStartCSSCoverage is similar to Page.StartCSSCoverage
+func (p *Page) MustStartCSSCoverage() (stop func() []*CSSCoverage) {
+	s, err := p.StartCSSCoverage()
+	p.e(err)
+	return func() []*CSSCoverage {
+		list, err := s()
+		p.e(err)
+		return list
+	}
+}
+
+// MustScreencast is similar to Page.Screencast
+func (p *Page) MustScreencast(req *proto.PageStartScreencast) (frames <-chan []byte, stop func()) {
+	frames, stop, err := p.Screencast(req)
+	p.e(err)
+	return frames, stop
+}
+
+// MustStartHAR is similar to Page.StartHAR
+func (p *Page) MustStartHAR() (stop func() []byte) {
+	s := p.StartHAR()
+	return func() []byte {
+		bin, err := s()
+		p.e(err)
+		return bin
+	}
+}
+
+// MustWaitDownload is similar to Page.WaitDownload
+func (p *Page) MustWaitDownload() (wait func() (path string)) {
+	w, _ := p.WaitDownload()
+	return func() string {
+		path, err := w()
+		p.e(err)
+		return path
+	}
+}
+
 // MustWaitOpen is similar to Page.WaitOpen
 func (p *Page) MustWaitOpen() (wait func() (newPage *Page)) {
 	w := p.WaitOpen()
@@ -395,6 +616,41 @@ func (p *Page) MustWaitNavigation() func() {
 	return p.WaitNavigation(proto.PageLifecycleEventNameNetworkAlmostIdle)
 }
 
+// MustWaitNavigated is similar to Page.WaitNavigated
+func (p *Page) MustWaitNavigated() (wait func()) {
+	w, _ := p.WaitNavigated()
+	return func() {
+		p.e(w())
+	}
+}
+
+// MustWaitRequest is similar to Page.WaitRequest
+func (p *Page) MustWaitRequest(pattern string) (wait func() *proto.NetworkRequest) {
+	w, _ := p.WaitRequest(pattern)
+	return func() *proto.NetworkRequest {
+		req, err := w()
+		p.e(err)
+		return req
+	}
+}
+
+// MustWaitResponse is similar to Page.WaitResponse
+func (p *Page) MustWaitResponse(pattern string) (wait func() (*proto.NetworkResponse, []byte)) {
+	w, _ := p.WaitResponse(pattern)
+	return func() (*proto.NetworkResponse, []byte) {
+		res, body, err := w()
+		p.e(err)
+		return res, body
+	}
+}
+
+// MustGetResponseBody is similar to Page.GetResponseBody
+func (p *Page) MustGetResponseBody(id proto.NetworkRequestID) []byte {
+	body, err := p.GetResponseBody(id)
+	p.e(err)
+	return body
+}
+
 // MustWaitRequestIdle is similar to Page.WaitRequestIdle
 func (p *Page) MustWaitRequestIdle(excludes ...string) (wait func()) {
 	return p.WaitRequestIdle(300*time.Millisecond, nil, excludes)
@@ -406,6 +662,12 @@ func (p *Page) MustWaitIdle() *Page {
 	return p
 }
 
+// MustWaitStable is similar to Page.WaitStable
+func (p *Page) MustWaitStable(d time.Duration) *Page {
+	p.e(p.WaitStable(d))
+	return p
+}
+
 // MustWaitLoad is similar to Page.WaitLoad
 func (p *Page) MustWaitLoad() *Page {
 	p.e(p.WaitLoad())
@@ -457,6 +719,12 @@ func (p *Page) MustWait(js string, params ...interface{}) *Page {
 	return p
 }
 
+// MustWaitInvisible is similar to Page.WaitInvisible
+func (p *Page) MustWaitInvisible(selector string) *Page {
+	p.e(p.WaitInvisible(selector))
+	return p
+}
+
 // MustWaitElementsMoreThan is similar to Page.WaitElementsMoreThan
 func (p *Page) MustWaitElementsMoreThan(selector string, num int) *Page {
 	p.e(p.WaitElementsMoreThan(selector, num))
@@ -531,6 +799,13 @@ func (p *Page) MustSearch(query string) *Element {
 	return res.First
 }
 
+// MustWaitVisible is similar to Page.WaitVisible
+func (p *Page) MustWaitVisible(selector string) *Element {
+	el, err := p.WaitVisible(selector)
+	p.e(err)
+	return el
+}
+
 // MustElement is similar to Page.Element
 func (p *Page) MustElement(selector string) *Element {
 	el, err := p.Element(selector)
@@ -630,6 +905,12 @@ func (m *Mouse) MustClick(button proto.InputMouseButton) *Mouse {
 	return m
 }
 
+// MustDrag is similar to Mouse.Drag
+func (m *Mouse) MustDrag(from, to proto.Point) *Mouse {
+	m.page.e(m.Drag(from, to, 5))
+	return m
+}
+
 // MustType is similar to Keyboard.Type
 func (k *Keyboard) MustType(key ...input.Key) *Keyboard {
 	k.page.e(k.Type(key...))
@@ -847,6 +1128,12 @@ func (p *Page) MustSetDocumentContent(html string) *Page {
 	return p
 }
 
+// MustSetContent is similar to Page.SetContent
+func (p *Page) MustSetContent(html, baseURL string) *Page {
+	p.e(p.SetContent(html, baseURL))
+	return p
+}
+
 // MustText is similar to Element.Text
 func (el *Element) MustText() string {
 	s, err := el.Text()
@@ -917,6 +1204,25 @@ func (el *Element) MustShape() *proto.DOMGetContentQuadsResult {
 	return shape
 }
 
+// MustHighlight is similar to Element.Highlight
+func (el *Element) MustHighlight(color *proto.DOMRGBA) *Element {
+	el.e(el.Highlight(color))
+	return el
+}
+
+// MustHideHighlight is similar to Element.HideHighlight
+func (el *Element) MustHideHighlight() *Element {
+	el.e(el.HideHighlight())
+	return el
+}
+
+// MustBox is similar to Element.Box
+func (el *Element) MustBox() *proto.DOMRect {
+	box, err := el.Box()
+	el.e(err)
+	return box
+}
+
 // MustCanvasToImage is similar to Element.CanvasToImage
 func (el *Element) MustCanvasToImage() []byte {
 	bin, err := el.CanvasToImage("", -1)
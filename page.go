@@ -1,12 +1,24 @@
 package rod
 
 import (
+	"bytes"
 	"context"
 	"crypto/md5"
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	htmlpkg "html"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"sync"
 	"time"
 
@@ -49,6 +61,9 @@ type Page struct {
 	// Used to abort all ongoing actions when a page closes.
 	sessionCancel func()
 
+	// Closed when the session of the page is detached, such as when the page is closed.
+	detached chan struct{}
+
 	root *Page
 
 	sleeper func() utils.Sleeper
@@ -83,6 +98,47 @@ func (p *Page) IsIframe() bool {
 	return p.element != nil
 }
 
+// Frames returns all the frames of the page as a flat list in depth-first order.
+// The first one is the main frame, its ParentID is empty.
+// Use Page.FrameByID to get a page instance for a frame.
+func (p *Page) Frames() ([]*proto.PageFrame, error) {
+	res, err := proto.PageGetFrameTree{}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*proto.PageFrame{}
+	var walk func(tree *proto.PageFrameTree)
+	walk = func(tree *proto.PageFrameTree) {
+		list = append(list, tree.Frame)
+		for _, child := range tree.ChildFrames {
+			walk(child)
+		}
+	}
+	walk(res.FrameTree)
+
+	return list, nil
+}
+
+// FrameByID creates a page instance that represents the frame with the id, check Element.Frame for details.
+func (p *Page) FrameByID(id proto.PageFrameID) (*Page, error) {
+	if id == p.FrameID {
+		return p, nil
+	}
+
+	owner, err := proto.DOMGetFrameOwner{FrameID: id}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	el, err := p.ElementFromNode(&proto.DOMNode{BackendNodeID: owner.BackendNodeID})
+	if err != nil {
+		return nil, err
+	}
+
+	return el.Frame()
+}
+
 // GetSessionID interface
 func (p *Page) GetSessionID() proto.TargetSessionID {
 	return p.SessionID
@@ -93,12 +149,15 @@ func (p *Page) Browser() *Browser {
 	return p.browser
 }
 
-// Info of the page, such as the URL or title of the page
+// Info of the page, such as the URL, title, and type of the page.
+// The OpenerID is the TargetID of the page that opened it, such as the opener of a popup,
+// it's useful to reconstruct the relationship of the pages.
 func (p *Page) Info() (*proto.TargetTargetInfo, error) {
 	return p.browser.pageInfo(p.TargetID)
 }
 
-// HTML of the page
+// HTML of the page, it's the outerHTML of the document element.
+// It reflects the live DOM after the javascript execution, not the original response body.
 func (p *Page) HTML() (string, error) {
 	el, err := p.Element("html")
 	if err != nil {
@@ -133,7 +192,183 @@ func (p *Page) SetCookies(cookies []*proto.NetworkCookieParam) error {
 	return proto.NetworkSetCookies{Cookies: cookies}.Call(p)
 }
 
+// CookieJar returns a http.CookieJar that holds the page cookies for the urls.
+// By default it will use the url of current page.
+// It's useful to hand off the session of the page to a http.Client.
+func (p *Page) CookieJar(urls []string) (http.CookieJar, error) {
+	if len(urls) == 0 {
+		info, err := p.Info()
+		if err != nil {
+			return nil, err
+		}
+		urls = []string{info.URL}
+	}
+
+	jar, _ := cookiejar.New(nil)
+
+	for _, u := range urls {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return nil, err
+		}
+
+		cookies, err := p.Cookies([]string{u})
+		if err != nil {
+			return nil, err
+		}
+
+		list := []*http.Cookie{}
+		for _, c := range cookies {
+			list = append(list, toHTTPCookie(c))
+		}
+		jar.SetCookies(parsed, list)
+	}
+
+	return jar, nil
+}
+
+// SetCookieJar sets the cookies of the jar for the urls to the page.
+// It's useful to hand off the session of a http.Client to the page.
+func (p *Page) SetCookieJar(jar http.CookieJar, urls []string) error {
+	list := []*proto.NetworkCookieParam{}
+	for _, u := range urls {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range jar.Cookies(parsed) {
+			list = append(list, &proto.NetworkCookieParam{
+				Name:  c.Name,
+				Value: c.Value,
+				URL:   u,
+			})
+		}
+	}
+
+	if len(list) == 0 {
+		return nil
+	}
+	return p.SetCookies(list)
+}
+
+func toHTTPCookie(c *proto.NetworkCookie) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Path:     c.Path,
+		HttpOnly: c.HTTPOnly,
+		Secure:   c.Secure,
+	}
+
+	// host-only cookies don't have the leading dot
+	if strings.HasPrefix(c.Domain, ".") {
+		cookie.Domain = c.Domain
+	}
+
+	if !c.Session {
+		cookie.Expires = c.Expires.Time()
+	}
+
+	return cookie
+}
+
+// LocalStorage returns the key-value pairs in the localStorage of the frame of the page.
+func (p *Page) LocalStorage() (map[string]string, error) {
+	return p.storageItems(true)
+}
+
+// SetLocalStorage sets the key-value pair to the localStorage of the frame of the page.
+// The page scripts may have read the storage before it's called, to seed a value before any script
+// of the page runs, use Page.EvalOnNewDocument instead, such as:
+//
+//	page.MustEvalOnNewDocument(`localStorage.setItem('flag', 'on')`)
+func (p *Page) SetLocalStorage(key, val string) error {
+	return p.setStorageItem(true, key, val)
+}
+
+// ClearLocalStorage removes all the key-value pairs in the localStorage of the frame of the page.
+func (p *Page) ClearLocalStorage() error {
+	return p.clearStorage(true)
+}
+
+// SessionStorage returns the key-value pairs in the sessionStorage of the frame of the page.
+// It works for iframes too, use Element.Frame to get the page of an iframe.
+// If the frame has no security origin, such as before any navigation, ErrNoStorageOrigin will be returned.
+func (p *Page) SessionStorage() (map[string]string, error) {
+	return p.storageItems(false)
+}
+
+// SetSessionStorage sets the key-value pair to the sessionStorage of the frame of the page.
+func (p *Page) SetSessionStorage(key, val string) error {
+	return p.setStorageItem(false, key, val)
+}
+
+// ClearSessionStorage removes all the key-value pairs in the sessionStorage of the frame of the page.
+func (p *Page) ClearSessionStorage() error {
+	return p.clearStorage(false)
+}
+
+// storageID of the frame of the page, the frame must have a security origin.
+func (p *Page) storageID(isLocal bool) (*proto.DOMStorageStorageID, error) {
+	frames, err := p.Frames()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range frames {
+		if f.ID == p.FrameID && f.SecurityOrigin != "" && f.SecurityOrigin != "null" && f.SecurityOrigin != "://" {
+			return &proto.DOMStorageStorageID{SecurityOrigin: f.SecurityOrigin, IsLocalStorage: isLocal}, nil
+		}
+	}
+	return nil, &ErrNoStorageOrigin{}
+}
+
+func (p *Page) storageItems(isLocal bool) (map[string]string, error) {
+	id, err := p.storageID(isLocal)
+	if err != nil {
+		return nil, err
+	}
+
+	defer p.EnableDomain(proto.DOMStorageEnable{})()
+
+	res, err := proto.DOMStorageGetDOMStorageItems{StorageID: id}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	items := map[string]string{}
+	for _, item := range res.Entries {
+		items[item[0]] = item[1]
+	}
+	return items, nil
+}
+
+func (p *Page) setStorageItem(isLocal bool, key, val string) error {
+	id, err := p.storageID(isLocal)
+	if err != nil {
+		return err
+	}
+
+	defer p.EnableDomain(proto.DOMStorageEnable{})()
+
+	return proto.DOMStorageSetDOMStorageItem{StorageID: id, Key: key, Value: val}.Call(p)
+}
+
+func (p *Page) clearStorage(isLocal bool) error {
+	id, err := p.storageID(isLocal)
+	if err != nil {
+		return err
+	}
+
+	defer p.EnableDomain(proto.DOMStorageEnable{})()
+
+	return proto.DOMStorageClear{StorageID: id}.Call(p)
+}
+
 // SetExtraHeaders whether to always send extra HTTP headers with the requests from this page.
+// The dict is a list of key-value pairs, such as []string{"Authorization", "Bearer token"}.
+// If the dict is empty, the previously set headers will be cleared.
 func (p *Page) SetExtraHeaders(dict []string) (func(), error) {
 	headers := proto.NetworkHeaders{}
 
@@ -177,36 +412,94 @@ func (p *Page) Navigate(url string) error {
 }
 
 // NavigateBack history.
+// If there's no previous history entry, ErrNoHistoryEntry will be returned. The check is skipped for iframes.
 func (p *Page) NavigateBack() error {
+	err := p.checkHistoryEntry(-1)
+	if err != nil {
+		return err
+	}
+
 	// Not using cdp API because it doesn't work for iframe
-	_, err := p.Evaluate(Eval(`() => history.back()`).ByUser())
+	_, err = p.Evaluate(Eval(`() => history.back()`).ByUser())
 	return err
 }
 
 // NavigateForward history.
+// If there's no next history entry, ErrNoHistoryEntry will be returned. The check is skipped for iframes.
 func (p *Page) NavigateForward() error {
+	err := p.checkHistoryEntry(1)
+	if err != nil {
+		return err
+	}
+
 	// Not using cdp API because it doesn't work for iframe
-	_, err := p.Evaluate(Eval(`() => history.forward()`).ByUser())
+	_, err = p.Evaluate(Eval(`() => history.forward()`).ByUser())
 	return err
 }
 
+func (p *Page) checkHistoryEntry(offset int) error {
+	if p.IsIframe() {
+		return nil
+	}
+
+	res, err := proto.PageGetNavigationHistory{}.Call(p)
+	if err != nil {
+		return err
+	}
+
+	i := res.CurrentIndex + offset
+	if i < 0 || i >= len(res.Entries) {
+		return &ErrNoHistoryEntry{}
+	}
+	return nil
+}
+
 // Reload page.
+// If a beforeunload dialog is dismissed, such as via Page.HandleDialog, ErrReloadCanceled will be returned.
 func (p *Page) Reload() error {
+	return p.reload(false)
+}
+
+// ReloadIgnoreCache is similar to Page.Reload, but the browser cache will be bypassed.
+// It doesn't work for iframes.
+func (p *Page) ReloadIgnoreCache() error {
+	return p.reload(true)
+}
+
+func (p *Page) reload(ignoreCache bool) error {
 	p, cancel := p.WithCancel()
 	defer cancel()
 
+	// only a dismissed beforeunload dialog cancels the reload,
+	// other dialogs may be opened and dismissed while the page is still loading
+	var dialogType proto.PageDialogType
+	canceled := false
 	wait := p.EachEvent(func(e *proto.PageFrameNavigated) bool {
 		return e.Frame.ID == p.FrameID
+	}, func(e *proto.PageJavascriptDialogOpening) {
+		dialogType = e.Type
+	}, func(e *proto.PageJavascriptDialogClosed) bool {
+		canceled = dialogType == proto.PageDialogTypeBeforeunload && !e.Result
+		return canceled
 	})
 
-	// Not using cdp API because it doesn't work for iframe
-	_, err := p.Evaluate(Eval(`() => location.reload()`).ByUser())
+	var err error
+	if ignoreCache {
+		err = proto.PageReload{IgnoreCache: true}.Call(p)
+	} else {
+		// Not using cdp API because it doesn't work for iframe
+		_, err = p.Evaluate(Eval(`() => location.reload()`).ByUser())
+	}
 	if err != nil {
 		return err
 	}
 
 	wait()
 
+	if canceled {
+		return &ErrReloadCanceled{}
+	}
+
 	p.unsetJSCtxID()
 
 	return nil
@@ -214,10 +507,22 @@ func (p *Page) Reload() error {
 
 // Activate (focuses) the page
 func (p *Page) Activate() (*Page, error) {
-	err := proto.TargetActivateTarget{TargetID: p.TargetID}.Call(p.browser)
+	err := proto.TargetActivateTarget{TargetID: p.TargetID}.Call(p.browser.Context(p.ctx))
 	return p, err
 }
 
+// ScrollTo scrolls the window of the page to the absolute position of the document.
+// To scroll to the bottom, such as to trigger the loading of an infinite list, you can use:
+//
+//	page.ScrollTo(0, page.MustEval(`() => document.body.scrollHeight`).Num())
+func (p *Page) ScrollTo(x, y float64) error {
+	defer p.tryTrace(TraceTypeInput, fmt.Sprintf("scroll to (%.2f, %.2f)", x, y))()
+	p.browser.trySlowmotion()
+
+	_, err := p.Evaluate(Eval(`(x, y) => window.scrollTo(x, y)`, x, y).ByUser())
+	return err
+}
+
 func (p *Page) getWindowID() (proto.BrowserWindowID, error) {
 	res, err := proto.BrowserGetWindowForTarget{TargetID: p.TargetID}.Call(p)
 	if err != nil {
@@ -252,7 +557,25 @@ func (p *Page) SetWindow(bounds *proto.BrowserBounds) error {
 	return err
 }
 
-// SetViewport overrides the values of device screen dimensions
+// Metrics returns the run-time performance metrics of the page as a name to value map,
+// such as "LayoutDuration", "ScriptDuration", "JSHeapUsedSize", and "Timestamp".
+func (p *Page) Metrics() (map[string]float64, error) {
+	defer p.EnableDomain(proto.PerformanceEnable{})()
+
+	res, err := proto.PerformanceGetMetrics{}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := map[string]float64{}
+	for _, m := range res.Metrics {
+		metrics[m.Name] = m.Value
+	}
+	return metrics, nil
+}
+
+// SetViewport overrides the values of device screen dimensions of the page.
+// It only affects the current page. If params is nil, the override will be cleared.
 func (p *Page) SetViewport(params *proto.EmulationSetDeviceMetricsOverride) error {
 	if params == nil {
 		return proto.EmulationClearDeviceMetricsOverride{}.Call(p)
@@ -260,6 +583,30 @@ func (p *Page) SetViewport(params *proto.EmulationSetDeviceMetricsOverride) erro
 	return params.Call(p)
 }
 
+// SetGeolocation overrides the geolocation of the page. If params is nil, the override will be cleared.
+// To let the page read it, the "geolocation" permission should be granted.
+func (p *Page) SetGeolocation(params *proto.EmulationSetGeolocationOverride) error {
+	if params == nil {
+		return proto.EmulationClearGeolocationOverride{}.Call(p)
+	}
+	return params.Call(p)
+}
+
+// SetTimezone overrides the timezone of the page with an IANA timezone id, such as "America/New_York".
+// If the tz is empty, the override will be cleared. If the browser doesn't know the tz, an error will be returned.
+func (p *Page) SetTimezone(tz string) error {
+	return proto.EmulationSetTimezoneOverride{TimezoneID: tz}.Call(p)
+}
+
+// EmulateMedia overrides the css media type and features of the page, such as the "print" media type or
+// the "prefers-color-scheme" feature. If params is nil, the override will be cleared.
+func (p *Page) EmulateMedia(params *proto.EmulationSetEmulatedMedia) error {
+	if params == nil {
+		params = &proto.EmulationSetEmulatedMedia{}
+	}
+	return params.Call(p)
+}
+
 // SetDocumentContent sets the page document html content
 func (p *Page) SetDocumentContent(html string) error {
 	return proto.PageSetDocumentContent{
@@ -268,7 +615,40 @@ func (p *Page) SetDocumentContent(html string) error {
 	}.Call(p)
 }
 
+// SetContent resets the page to "about:blank", then sets the html as its document and waits for the load event.
+// It's handy to test a snippet of html without a web server.
+// If baseURL is not empty, a <base> element will be inserted, so that the relative urls in the html,
+// such as the src of a script, will be resolved against it.
+func (p *Page) SetContent(html, baseURL string) error {
+	err := p.Navigate("about:blank")
+	if err != nil {
+		return err
+	}
+
+	if baseURL != "" {
+		base := `<base href="` + htmlpkg.EscapeString(baseURL) + `">`
+
+		// keep the doctype the first, or the document will be in the quirks mode
+		if loc := regDoctype.FindStringIndex(html); loc != nil {
+			html = html[:loc[1]] + base + html[loc[1]:]
+		} else {
+			html = base + html
+		}
+	}
+
+	err = p.SetDocumentContent(html)
+	if err != nil {
+		return err
+	}
+
+	return p.WaitLoad()
+}
+
+var regDoctype = regexp.MustCompile(`(?i)\A\s*<!doctype[^>]*>`)
+
 // Emulate the device, such as iPhone9. If device is devices.Clear, it will clear the override.
+// The screen metrics (including the mobile flag and device scale factor), touch emulation, and user agent
+// of the device will be applied.
 func (p *Page) Emulate(device devices.Device) error {
 	err := p.SetViewport(device.MetricsEmulation())
 	if err != nil {
@@ -289,6 +669,7 @@ func (p *Page) StopLoading() error {
 }
 
 // Close tries to close page, running its beforeunload hooks, if has any.
+// After the page is closed, the calls on it will return ErrPageClosed.
 func (p *Page) Close() error {
 	p.browser.targetsLock.Lock()
 	defer p.browser.targetsLock.Unlock()
@@ -363,6 +744,18 @@ func (p *Page) HandleDialog() (
 		}
 }
 
+// AutoHandleDialogs accepts or dismisses all the JavaScript initiated dialogs (alert, confirm, prompt, or onbeforeunload)
+// until the returned stop is called. The promptText will be used as the input of the prompt dialogs.
+func (p *Page) AutoHandleDialogs(accept bool, promptText string) (stop func()) {
+	p, cancel := p.WithCancel()
+
+	go p.EachEvent(func(e *proto.PageJavascriptDialogOpening) {
+		_ = proto.PageHandleJavaScriptDialog{Accept: accept, PromptText: promptText}.Call(p)
+	})()
+
+	return cancel
+}
+
 // HandleFileDialog return a functions that waits for the next file chooser dialog pops up and returns the element
 // for the event.
 func (p *Page) HandleFileDialog() (func([]string) error, error) {
@@ -389,7 +782,16 @@ func (p *Page) HandleFileDialog() (func([]string) error, error) {
 	}, nil
 }
 
+// screenshotMaxHeight is the common max texture size of the GPUs in device pixels,
+// the browser can't capture a taller image at once
+const screenshotMaxHeight = 16384
+
 // Screenshot captures the screenshot of current page.
+// Use the req to set the image format, such as jpeg with a quality to shrink the output.
+// If fullpage is true, the viewport will be temporarily resized to the content size of the page.
+// For a page taller than 16384 device pixels, the page will be scrolled to capture it tile by tile,
+// then the tiles will be stitched into one image, the fixed elements will appear on every tile,
+// and only png and jpeg are supported, ErrStitchFormat will be returned for other formats.
 func (p *Page) Screenshot(fullpage bool, req *proto.PageCaptureScreenshot) ([]byte, error) {
 	if req == nil {
 		req = &proto.PageCaptureScreenshot{}
@@ -410,6 +812,26 @@ func (p *Page) Screenshot(fullpage bool, req *proto.PageCaptureScreenshot) ([]by
 		view.Width = int(metrics.CSSContentSize.Width)
 		view.Height = int(metrics.CSSContentSize.Height)
 
+		scale := view.DeviceScaleFactor
+		if scale == 0 {
+			res, err := p.Eval(`() => window.devicePixelRatio`)
+			if err != nil {
+				return nil, err
+			}
+			scale = res.Value.Num()
+		}
+
+		maxHeight := int(screenshotMaxHeight / scale)
+		stitch := view.Height > maxHeight
+		if stitch {
+			if req.Format != "" &&
+				req.Format != proto.PageCaptureScreenshotFormatPng &&
+				req.Format != proto.PageCaptureScreenshotFormatJpeg {
+				return nil, &ErrStitchFormat{req.Format}
+			}
+			view.Height = maxHeight
+		}
+
 		err = p.SetViewport(&view)
 		if err != nil {
 			return nil, err
@@ -423,6 +845,10 @@ func (p *Page) Screenshot(fullpage bool, req *proto.PageCaptureScreenshot) ([]by
 
 			_ = p.SetViewport(&oldView)
 		}()
+
+		if stitch {
+			return p.screenshotStitch(req, view.Height, int(metrics.CSSContentSize.Height))
+		}
 	}
 
 	shot, err := req.Call(p)
@@ -432,8 +858,112 @@ func (p *Page) Screenshot(fullpage bool, req *proto.PageCaptureScreenshot) ([]by
 	return shot.Data, nil
 }
 
-// PDF prints page as PDF
+// screenshotStitch scrolls the page to capture each tile of the page, then draws them into one image
+func (p *Page) screenshotStitch(req *proto.PageCaptureScreenshot, tileHeight, height int) ([]byte, error) {
+	origin, err := p.Eval(`() => [window.scrollX, window.scrollY]`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_, _ = p.Eval(`(x, y) => window.scrollTo(x, y)`, origin.Value.Get("0"), origin.Value.Get("1"))
+	}()
+
+	var canvas *image.RGBA
+	for y := 0; y < height; y += tileHeight {
+		// the scroll of the last tile may be clamped by the bottom of the page
+		scrolled, err := p.Eval(`y => { window.scrollTo(0, y); return window.scrollY }`, y)
+		if err != nil {
+			return nil, err
+		}
+
+		err = p.WaitRepaint()
+		if err != nil {
+			return nil, err
+		}
+
+		shot, err := proto.PageCaptureScreenshot{
+			Format:      proto.PageCaptureScreenshotFormatPng,
+			FromSurface: req.FromSurface,
+		}.Call(p)
+		if err != nil {
+			return nil, err
+		}
+
+		tile, err := png.Decode(bytes.NewReader(shot.Data))
+		if err != nil {
+			return nil, err
+		}
+
+		// the size of the image is affected by the device scale factor
+		scale := float64(tile.Bounds().Dy()) / float64(tileHeight)
+		if canvas == nil {
+			canvas = image.NewRGBA(image.Rect(0, 0, tile.Bounds().Dx(), int(float64(height)*scale)))
+		}
+
+		top := int(float64(y) * scale)
+		offset := int(float64(y-scrolled.Value.Int()) * scale)
+		draw.Draw(canvas, image.Rect(0, top, canvas.Bounds().Dx(), canvas.Bounds().Dy()), tile, image.Pt(0, offset), draw.Src)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if req.Format == proto.PageCaptureScreenshotFormatJpeg {
+		var opts *jpeg.Options
+		if req.Quality != nil {
+			opts = &jpeg.Options{Quality: *req.Quality}
+		}
+		err = jpeg.Encode(buf, canvas, opts)
+	} else {
+		err = png.Encode(buf, canvas)
+	}
+	return buf.Bytes(), err
+}
+
+// Screencast starts to stream the frames of the page, each frame is an image encoded by the format of the req,
+// the default format is jpeg. Frames are only produced when the page repaints.
+// Each frame is acknowledged as soon as it's received, so a slow consumer won't stall the browser,
+// but the frames must be consumed, or the frames after it will be buffered in memory.
+// Call stop to end the streaming, the frames channel will be closed after that.
+func (p *Page) Screencast(req *proto.PageStartScreencast) (frames <-chan []byte, stop func(), err error) {
+	if req == nil {
+		req = &proto.PageStartScreencast{}
+	}
+
+	ep, cancel := p.WithCancel()
+	ch := make(chan []byte)
+
+	wait := ep.EachEvent(func(e *proto.PageScreencastFrame) {
+		_ = proto.PageScreencastFrameAck{SessionID: e.SessionID}.Call(ep)
+
+		select {
+		case <-ep.ctx.Done():
+		case ch <- e.Data:
+		}
+	})
+
+	go func() {
+		defer close(ch)
+		wait()
+	}()
+
+	err = req.Call(p)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	return ch, func() {
+		_ = proto.PageStopScreencast{}.Call(p)
+		cancel()
+	}, nil
+}
+
+// PDF prints page as PDF.
+// Use the req to control the layout, such as landscape, paper size, margins, and printBackground.
+// If the req is nil, the default options will be used.
 func (p *Page) PDF(req *proto.PagePrintToPDF) (*StreamReader, error) {
+	if req == nil {
+		req = &proto.PagePrintToPDF{}
+	}
 	req.TransferMode = proto.PagePrintToPDFTransferModeReturnAsStream
 	res, err := req.Call(p)
 	if err != nil {
@@ -443,6 +973,267 @@ func (p *Page) PDF(req *proto.PagePrintToPDF) (*StreamReader, error) {
 	return NewStreamReader(p, res.Stream), nil
 }
 
+// StartTracing starts to record a performance trace of the page. Call stop to end the recording and get
+// the trace data, it's json that can be opened by the "Performance" panel of Chrome DevTools or chrome://tracing.
+// If categories is empty, the default categories of the browser will be used.
+func (p *Page) StartTracing(categories []string) (stop func() (*StreamReader, error), err error) {
+	err = proto.TracingStart{
+		TransferMode: proto.TracingStartTransferModeReturnAsStream,
+		TraceConfig:  &proto.TracingTraceConfig{IncludedCategories: categories},
+	}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() (*StreamReader, error) {
+		var e proto.TracingTracingComplete
+		wait := p.WaitEvent(&e)
+
+		err := proto.TracingEnd{}.Call(p)
+		if err != nil {
+			return nil, err
+		}
+
+		wait()
+		if e.Stream == "" {
+			return nil, p.ctx.Err()
+		}
+
+		return NewStreamReader(p, e.Stream), nil
+	}, nil
+}
+
+// JSCoverage of a script. The embedded ProfilerScriptCoverage is in the V8 format,
+// tools like v8-to-istanbul can convert it with the Source to Istanbul or lcov format.
+type JSCoverage struct {
+	*proto.ProfilerScriptCoverage
+
+	// Source of the script
+	Source string
+}
+
+// StartJSCoverage starts to collect the block-level coverage of the JS that runs on the page.
+// Call stop to end the collection and get the byte ranges of each script, scripts without url are skipped,
+// such as the ones created by eval.
+func (p *Page) StartJSCoverage() (stop func() ([]*JSCoverage, error), err error) {
+	restoreDebugger := p.EnableDomain(proto.DebuggerEnable{})
+	restoreProfiler := p.EnableDomain(proto.ProfilerEnable{})
+	restore := func() {
+		restoreProfiler()
+		restoreDebugger()
+	}
+
+	_, err = proto.ProfilerStartPreciseCoverage{CallCount: true, Detailed: true}.Call(p)
+	if err != nil {
+		restore()
+		return nil, err
+	}
+
+	return func() ([]*JSCoverage, error) {
+		defer restore()
+
+		res, err := proto.ProfilerTakePreciseCoverage{}.Call(p)
+		if err != nil {
+			return nil, err
+		}
+
+		err = proto.ProfilerStopPreciseCoverage{}.Call(p)
+		if err != nil {
+			return nil, err
+		}
+
+		list := []*JSCoverage{}
+		for _, s := range res.Result {
+			if s.URL == "" {
+				continue
+			}
+
+			src, err := proto.DebuggerGetScriptSource{ScriptID: s.ScriptID}.Call(p)
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, &JSCoverage{s, src.ScriptSource})
+		}
+		return list, nil
+	}, nil
+}
+
+// CSSCoverage of a stylesheet
+type CSSCoverage struct {
+	// Header of the stylesheet, such as the SourceURL. It's nil if the header is unknown.
+	Header *proto.CSSCSSStyleSheetHeader
+
+	// Source of the stylesheet
+	Source string
+
+	// Rules of the stylesheet, use the Used field to tell if the byte range of a rule is used
+	Rules []*proto.CSSRuleUsage
+}
+
+// StartCSSCoverage starts to track which CSS rules are used by the page.
+// Call stop to end the tracking and get the rule usage of each stylesheet.
+func (p *Page) StartCSSCoverage() (stop func() ([]*CSSCoverage, error), err error) {
+	lock := sync.Mutex{}
+	headers := map[proto.CSSStyleSheetID]*proto.CSSCSSStyleSheetHeader{}
+
+	// subscribe before the CSS domain is enabled, it emits the existing stylesheets once enabled
+	ep, cancel := p.WithCancel()
+	events := ep.Event()
+	go func() {
+		for msg := range events {
+			e := proto.CSSStyleSheetAdded{}
+			if msg.Load(&e) {
+				lock.Lock()
+				headers[e.Header.StyleSheetID] = e.Header
+				lock.Unlock()
+			}
+		}
+	}()
+
+	restoreDOM := p.EnableDomain(proto.DOMEnable{})
+	restoreCSS := p.EnableDomain(proto.CSSEnable{})
+	restore := func() {
+		cancel()
+		restoreCSS()
+		restoreDOM()
+	}
+
+	err = proto.CSSStartRuleUsageTracking{}.Call(p)
+	if err != nil {
+		restore()
+		return nil, err
+	}
+
+	return func() ([]*CSSCoverage, error) {
+		defer restore()
+
+		res, err := proto.CSSStopRuleUsageTracking{}.Call(p)
+		if err != nil {
+			return nil, err
+		}
+
+		list := []*CSSCoverage{}
+		dict := map[proto.CSSStyleSheetID]*CSSCoverage{}
+		for _, r := range res.RuleUsage {
+			cov, has := dict[r.StyleSheetID]
+			if !has {
+				src, err := proto.CSSGetStyleSheetText{StyleSheetID: r.StyleSheetID}.Call(p)
+				if err != nil {
+					return nil, err
+				}
+
+				lock.Lock()
+				cov = &CSSCoverage{Header: headers[r.StyleSheetID], Source: src.Text}
+				lock.Unlock()
+
+				dict[r.StyleSheetID] = cov
+				list = append(list, cov)
+			}
+			cov.Rules = append(cov.Rules, r)
+		}
+		return list, nil
+	}, nil
+}
+
+// GetResponseBody of the request, the request id can be got from the events of the Network domain,
+// such as the proto.NetworkResponseReceived. The body is only available after the loading is finished,
+// and the browser may evict it from the memory after a while.
+func (p *Page) GetResponseBody(id proto.NetworkRequestID) ([]byte, error) {
+	res, err := proto.NetworkGetResponseBody{RequestID: id}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Base64Encoded {
+		return base64.StdEncoding.DecodeString(res.Body)
+	}
+	return []byte(res.Body), nil
+}
+
+// WaitRequest returns a wait function that waits for the next request whose url matches the regexp pattern,
+// and returns the request once it's sent, such as its method, url, headers, and post data. Call cancel to stop the waiting.
+// It's useful to assert the payload of the API calls the page makes without hijacking them.
+func (p *Page) WaitRequest(pattern string) (wait func() (*proto.NetworkRequest, error), cancel func()) {
+	p, cancel = p.WithCancel()
+	match := genRegMatcher([]string{pattern}, nil)
+
+	var sent *proto.NetworkRequestWillBeSent
+
+	w := p.EachEvent(func(e *proto.NetworkRequestWillBeSent) bool {
+		if match(e.Request.URL) {
+			sent = e
+		}
+		return sent != nil
+	})
+
+	return func() (*proto.NetworkRequest, error) {
+		defer cancel()
+		w()
+
+		if sent == nil {
+			return nil, p.ctx.Err()
+		}
+
+		// the post data will be omitted from the event if it's too long
+		req := sent.Request
+		if req.HasPostData && req.PostData == "" {
+			res, err := proto.NetworkGetRequestPostData{RequestID: sent.RequestID}.Call(p)
+			if err != nil {
+				return nil, err
+			}
+			req.PostData = res.PostData
+		}
+		return req, nil
+	}, cancel
+}
+
+// WaitResponse returns a wait function that waits for the next response whose url matches the regexp pattern,
+// and returns the response with its body once the loading is finished. Call cancel to stop the waiting.
+// It's useful to get the payload of the API calls the page makes without hijacking them.
+func (p *Page) WaitResponse(pattern string) (wait func() (*proto.NetworkResponse, []byte, error), cancel func()) {
+	p, cancel = p.WithCancel()
+	match := genRegMatcher([]string{pattern}, nil)
+
+	var id proto.NetworkRequestID
+	var res *proto.NetworkResponse
+	var failed *proto.NetworkLoadingFailed
+	finished := false
+
+	w := p.EachEvent(func(e *proto.NetworkResponseReceived) {
+		if res == nil && match(e.Response.URL) {
+			id = e.RequestID
+			res = e.Response
+		}
+	}, func(e *proto.NetworkLoadingFinished) bool {
+		finished = res != nil && e.RequestID == id
+		return finished
+	}, func(e *proto.NetworkLoadingFailed) bool {
+		if res != nil && e.RequestID == id {
+			failed = e
+		}
+		return failed != nil
+	})
+
+	return func() (*proto.NetworkResponse, []byte, error) {
+		defer cancel()
+		w()
+
+		if failed != nil {
+			return nil, nil, &ErrRequestFailed{URL: res.URL, ErrorText: failed.ErrorText}
+		}
+		if !finished {
+			return nil, nil, p.ctx.Err()
+		}
+
+		body, err := p.GetResponseBody(id)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res, body, nil
+	}, cancel
+}
+
 // GetResource content by the url. Such as image, css, html, etc.
 // Use the proto.PageGetResourceTree to list all the resources.
 func (p *Page) GetResource(url string) ([]byte, error) {
@@ -467,6 +1258,52 @@ func (p *Page) GetResource(url string) ([]byte, error) {
 	return bin, nil
 }
 
+// WaitDownload returns a wait function that waits for the next download started by the page to finish,
+// and returns the path of the saved file. Call cancel to stop the waiting.
+// The download path must be set by Browser.SetDownloadPath first, or ErrDownloadPathNotSet will be returned.
+// If the download is canceled or fails, ErrDownloadCanceled will be returned.
+func (p *Page) WaitDownload() (wait func() (string, error), cancel func()) {
+	p, cancel = p.WithCancel()
+
+	var start *proto.PageDownloadWillBegin
+	var state proto.PageDownloadProgressState
+
+	w := p.EachEvent(func(e *proto.PageDownloadWillBegin) {
+		if start == nil {
+			start = e
+		}
+	}, func(e *proto.PageDownloadProgress) bool {
+		if start != nil && start.GUID == e.GUID && e.State != proto.PageDownloadProgressStateInProgress {
+			state = e.State
+		}
+		return state != ""
+	})
+
+	return func() (string, error) {
+		defer cancel()
+
+		var behavior proto.BrowserSetDownloadBehavior
+		if !p.browser.LoadState("", &behavior) || behavior.DownloadPath == "" {
+			return "", &ErrDownloadPathNotSet{}
+		}
+
+		w()
+
+		switch state {
+		case "":
+			return "", p.ctx.Err()
+		case proto.PageDownloadProgressStateCanceled:
+			return "", &ErrDownloadCanceled{URL: start.URL}
+		}
+
+		name := start.SuggestedFilename
+		if behavior.Behavior == proto.BrowserSetDownloadBehaviorBehaviorAllowAndName {
+			name = start.GUID
+		}
+		return filepath.Join(behavior.DownloadPath, name), nil
+	}, cancel
+}
+
 // WaitOpen waits for the next new page opened by the current one
 func (p *Page) WaitOpen() func() (*Page, error) {
 	var targetID proto.TargetTargetID
@@ -499,6 +1336,11 @@ func (p *Page) WaitOpen() func() (*Page, error) {
 //	go page.EachEvent(func(e *proto.PageJavascriptDialogOpening) {
 //	    _ = proto.PageHandleJavaScriptDialog{ Accept: false, PromptText: ""}.Call(page)
 //	})()
+//
+// The subscription is continuous until a callback returns true or the context of the page is canceled.
+// The callbacks are called one by one in the goroutine that runs the wait function. Each subscription has
+// its own event queue, so a slow callback only delays its own subscription, it won't block the event pump
+// of the browser or other subscriptions.
 func (p *Page) EachEvent(callbacks ...interface{}) (wait func()) {
 	return p.browser.Context(p.ctx).eachEvent(p.SessionID, callbacks...)
 }
@@ -510,12 +1352,15 @@ func (p *Page) WaitEvent(e proto.Event) (wait func()) {
 }
 
 // WaitNavigation wait for a page lifecycle event when navigating.
-// Usually you will wait for proto.PageLifecycleEventNameNetworkAlmostIdle
+// Usually you will wait for proto.PageLifecycleEventNameNetworkAlmostIdle, other common ones are
+// proto.PageLifecycleEventNameDOMContentLoaded, proto.PageLifecycleEventNameLoad,
+// proto.PageLifecycleEventNameNetworkIdle, and proto.PageLifecycleEventNameFirstMeaningfulPaint.
+// Only the events of the frame of the page count, the ones of its iframes are ignored.
 func (p *Page) WaitNavigation(name proto.PageLifecycleEventName) func() {
 	_ = proto.PageSetLifecycleEventsEnabled{Enabled: true}.Call(p)
 
 	wait := p.EachEvent(func(e *proto.PageLifecycleEvent) bool {
-		return e.Name == name
+		return e.FrameID == p.FrameID && e.Name == name
 	})
 
 	return func() {
@@ -525,6 +1370,34 @@ func (p *Page) WaitNavigation(name proto.PageLifecycleEventName) func() {
 	}
 }
 
+// WaitNavigated returns a wait function that waits until the frame of the page is navigated,
+// either by a full navigation or by a same-document navigation such as history.pushState or anchor changes.
+// It's useful to wait for a route change of single-page applications after a click.
+// The wait function returns the context error if the page context is done before the navigation happens.
+// Call the cancel function to release the subscription if you won't call the wait function.
+func (p *Page) WaitNavigated() (wait func() error, cancel func()) {
+	p, cancel = p.WithCancel()
+
+	navigated := false
+	w := p.EachEvent(func(e *proto.PageFrameNavigated) bool {
+		navigated = e.Frame.ID == p.FrameID
+		return navigated
+	}, func(e *proto.PageNavigatedWithinDocument) bool {
+		navigated = e.FrameID == p.FrameID
+		return navigated
+	})
+
+	return func() error {
+		defer p.tryTrace(TraceTypeWait, "navigated")()
+		defer cancel()
+		w()
+		if !navigated {
+			return p.ctx.Err()
+		}
+		return nil
+	}, cancel
+}
+
 // WaitRequestIdle returns a wait function that waits until no request for d duration.
 // Be careful, d is not the max wait timeout, it's the least idle time.
 // If you want to set a timeout you can use the "Page.Timeout" function.
@@ -580,6 +1453,16 @@ func (p *Page) WaitIdle(timeout time.Duration) (err error) {
 	return err
 }
 
+// WaitStable waits until there's no DOM mutation in the page for the duration d, such as to wait for
+// the progressive rendering to finish before taking a screenshot. It's different from Element.WaitStable
+// which checks the shape of an element, the css animations and transitions don't mutate the DOM.
+// Use Page.Timeout to limit the wait time, a page that keeps changing will never be stable.
+func (p *Page) WaitStable(d time.Duration) error {
+	defer p.tryTrace(TraceTypeWait, "stable")()
+	_, err := p.Evaluate(evalHelper(js.WaitDOMStable, d.Milliseconds()).ByPromise())
+	return err
+}
+
 // WaitRepaint waits until the next repaint.
 // Doc: https://developer.mozilla.org/en-US/docs/Web/API/window/requestAnimationFrame
 func (p *Page) WaitRepaint() error {
@@ -589,6 +1472,8 @@ func (p *Page) WaitRepaint() error {
 }
 
 // WaitLoad waits for the `window.onload` event, it returns immediately if the event is already fired.
+// The document.readyState is checked first, so it won't hang if the event fired before the call.
+// Use Page.Timeout to limit the wait time.
 func (p *Page) WaitLoad() error {
 	defer p.tryTrace(TraceTypeWait, "load")()
 	_, err := p.Evaluate(evalHelper(js.WaitLoad).ByPromise())
@@ -627,7 +1512,12 @@ func (p *Page) EvalOnNewDocument(js string) (remove func() error, err error) {
 	return
 }
 
-// Wait until the js returns true
+// Wait until the js returns true. The js is evaluated repeatedly with the page sleeper until it returns true
+// or the page context is done, such as:
+//
+//	page.Wait(rod.Eval(`(k) => window[k] === true`, "appLoaded"))
+//
+// If the js throws, the waiting will stop and ErrEval will be returned.
 func (p *Page) Wait(opts *EvalOptions) error {
 	return utils.Retry(p.ctx, p.sleeper(), func() (bool, error) {
 		res, err := p.Evaluate(opts)
@@ -639,11 +1529,225 @@ func (p *Page) Wait(opts *EvalOptions) error {
 	})
 }
 
+// WaitInvisible until all the elements that match the css selector are invisible or removed,
+// it returns immediately if no element matches. Check Element.WaitVisible for what is visible.
+// It's useful to wait for a loading spinner to disappear.
+// The timeout comes from the context of the page, such as Page.Timeout.
+func (p *Page) WaitInvisible(selector string) error {
+	defer p.tryTrace(TraceTypeWait, "invisible", selector)()
+	return p.Wait(evalHelper(js.InvisibleElements, selector))
+}
+
 // WaitElementsMoreThan Wait until there are more than <num> <selector> elements.
 func (p *Page) WaitElementsMoreThan(selector string, num int) error {
 	return p.Wait(Eval(`(s, n) => document.querySelectorAll(s).length > n`, selector, num))
 }
 
+// ConsoleMessage from the console of the page
+type ConsoleMessage struct {
+	// Level of the message, such as "log", "error", "warning" from the console API,
+	// or "verbose", "info", "warning", "error" from the browser, such as a failed resource.
+	Level string
+
+	// Text of the message, the arguments are joined with space
+	Text string
+
+	// Args of the console API call, use Page.ObjectToJSON to get the value of an object.
+	// The Preview field of an object has the summary of its properties.
+	Args []*proto.RuntimeRemoteObject
+
+	// URL of the source that creates the message if known
+	URL string
+
+	// LineNumber in the source, it's zero-based
+	LineNumber int
+}
+
+// Console streams the messages from the console API and the browser log of the page until cancel is called.
+// The events must be consumed, or the messages after it will be blocked.
+func (p *Page) Console() (events <-chan *ConsoleMessage, cancel func()) {
+	p, cancel = p.WithCancel()
+	ch := make(chan *ConsoleMessage)
+
+	send := func(msg *ConsoleMessage) {
+		select {
+		case <-p.ctx.Done():
+		case ch <- msg:
+		}
+	}
+
+	wait := p.EachEvent(func(e *proto.RuntimeConsoleAPICalled) {
+		texts := []string{}
+		for _, arg := range e.Args {
+			texts = append(texts, consoleArgText(arg))
+		}
+
+		msg := &ConsoleMessage{
+			Level: string(e.Type),
+			Text:  strings.Join(texts, " "),
+			Args:  e.Args,
+		}
+		if e.StackTrace != nil && len(e.StackTrace.CallFrames) > 0 {
+			msg.URL = e.StackTrace.CallFrames[0].URL
+			msg.LineNumber = e.StackTrace.CallFrames[0].LineNumber
+		}
+		send(msg)
+	}, func(e *proto.LogEntryAdded) {
+		msg := &ConsoleMessage{
+			Level: string(e.Entry.Level),
+			Text:  e.Entry.Text,
+			Args:  e.Entry.Args,
+			URL:   e.Entry.URL,
+		}
+		if e.Entry.LineNumber != nil {
+			msg.LineNumber = *e.Entry.LineNumber
+		}
+		send(msg)
+	})
+
+	go func() {
+		defer close(ch)
+		wait()
+	}()
+
+	return ch, cancel
+}
+
+// FailOnConsoleError records the console.error calls and the uncaught exceptions of the page until cancel is called.
+// The check returns ErrConsoleErrors if anything is recorded, it's handy for the teardown of a test, such as:
+//
+//	check, cancel := page.FailOnConsoleError()
+//	defer func() {
+//		cancel()
+//		if err := check(); err != nil {
+//			t.Error(err)
+//		}
+//	}()
+func (p *Page) FailOnConsoleError() (check func() error, cancel func()) {
+	p, cancel = p.WithCancel()
+
+	var lock sync.Mutex
+	list := []string{}
+	add := func(text string) {
+		lock.Lock()
+		defer lock.Unlock()
+		list = append(list, text)
+	}
+
+	wait := p.EachEvent(func(e *proto.RuntimeConsoleAPICalled) {
+		if e.Type != proto.RuntimeConsoleAPICalledTypeError {
+			return
+		}
+
+		texts := []string{}
+		for _, arg := range e.Args {
+			texts = append(texts, consoleArgText(arg))
+		}
+		add(strings.Join(texts, " ") + jsStackText(e.StackTrace))
+	}, func(e *proto.RuntimeExceptionThrown) {
+		d := e.ExceptionDetails
+
+		// the description of an Error object already contains the stack
+		if d.Exception != nil && d.Exception.Description != "" {
+			add(d.Exception.Description)
+			return
+		}
+
+		text := d.Text
+		if d.Exception != nil {
+			text += " " + consoleArgText(d.Exception)
+		}
+		add(text + jsStackText(d.StackTrace))
+	})
+
+	go wait()
+
+	return func() error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if len(list) == 0 {
+			return nil
+		}
+		return &ErrConsoleErrors{Errors: append([]string{}, list...)}
+	}, cancel
+}
+
+// jsStackText formats the stack like the one of the js Error.stack
+func jsStackText(stack *proto.RuntimeStackTrace) string {
+	if stack == nil {
+		return ""
+	}
+
+	text := ""
+	for _, f := range stack.CallFrames {
+		name := f.FunctionName
+		if name == "" {
+			name = "<anonymous>"
+		}
+		text += fmt.Sprintf("\n    at %s (%s:%d:%d)", name, f.URL, f.LineNumber+1, f.ColumnNumber+1)
+	}
+	return text
+}
+
+func consoleArgText(obj *proto.RuntimeRemoteObject) string {
+	switch {
+	case obj.Type == proto.RuntimeRemoteObjectTypeUndefined:
+		return "undefined"
+	case obj.Subtype == proto.RuntimeRemoteObjectSubtypeNull:
+		return "null"
+	case obj.UnserializableValue != "":
+		return string(obj.UnserializableValue)
+	case obj.Type == proto.RuntimeRemoteObjectTypeString || obj.ObjectID == "":
+		return obj.Value.String()
+	}
+	return obj.Description
+}
+
+// RequestFailed is the info of a network request that failed to load
+type RequestFailed struct {
+	// URL of the request
+	URL string
+
+	// Type of the resource, such as "Document", "Script", "XHR"
+	Type proto.NetworkResourceType
+
+	// ErrorText from the browser, such as "net::ERR_NAME_NOT_RESOLVED"
+	ErrorText string
+
+	// Canceled is true if the request is canceled, such as the page navigates away before it finishes
+	Canceled bool
+
+	// BlockedReason is not empty if the request is blocked, such as by CSP or the mixed content policy
+	BlockedReason proto.NetworkBlockedReason
+}
+
+// EachRequestFailed calls the fn for each network request of the page that fails to load until cancel is called.
+// A request that gets a http error status, such as 404, is not a failure, because the response is loaded.
+func (p *Page) EachRequestFailed(fn func(*RequestFailed)) (cancel func()) {
+	p, cancel = p.WithCancel()
+	urls := map[proto.NetworkRequestID]string{}
+
+	go p.EachEvent(func(e *proto.NetworkRequestWillBeSent) {
+		urls[e.RequestID] = e.Request.URL
+	}, func(e *proto.NetworkLoadingFinished) {
+		delete(urls, e.RequestID)
+	}, func(e *proto.NetworkLoadingFailed) {
+		u := urls[e.RequestID]
+		delete(urls, e.RequestID)
+
+		fn(&RequestFailed{
+			URL:           u,
+			Type:          e.Type,
+			ErrorText:     e.ErrorText,
+			Canceled:      e.Canceled,
+			BlockedReason: e.BlockedReason,
+		})
+	})()
+
+	return cancel
+}
+
 // ObjectToJSON by object id
 func (p *Page) ObjectToJSON(obj *proto.RuntimeRemoteObject) (gson.JSON, error) {
 	if obj.ObjectID == "" {
@@ -680,13 +1784,17 @@ func (p *Page) ElementFromObject(obj *proto.RuntimeRemoteObject) (*Element, erro
 		p = &clone
 	}
 
+	return p.newElement(obj), nil
+}
+
+func (p *Page) newElement(obj *proto.RuntimeRemoteObject) *Element {
 	return &Element{
 		e:       p.e,
 		ctx:     p.ctx,
 		sleeper: p.sleeper,
 		page:    p,
 		Object:  obj,
-	}, nil
+	}
 }
 
 // ElementFromNode creates an Element from the node, NodeID or BackendNodeID must be specified.
@@ -742,7 +1850,22 @@ func (p *Page) Release(obj *proto.RuntimeRemoteObject) error {
 
 // Call implements the proto.Client
 func (p *Page) Call(ctx context.Context, sessionID, methodName string, params interface{}) (res []byte, err error) {
-	return p.browser.Call(ctx, sessionID, methodName, params)
+	res, err = p.browser.Call(ctx, sessionID, methodName, params)
+	if err != nil && (errors.Is(err, cdp.ErrSessionNotFound) || p.isDetached()) {
+		err = &ErrPageClosed{err}
+	}
+	return
+}
+
+// After the session is detached the calls are canceled by the session ctx
+// instead of being rejected by the browser, both mean the page is closed.
+func (p *Page) isDetached() bool {
+	select {
+	case <-p.detached:
+		return true
+	default:
+		return false
+	}
 }
 
 // Event of the page
@@ -783,6 +1906,7 @@ func (p *Page) initEvents() {
 
 			if (msg.Load(&detached) && detached.SessionID == p.SessionID) ||
 				(msg.Load(destroyed) && destroyed.TargetID == p.TargetID) {
+				close(p.detached)
 				p.sessionCancel()
 				return
 			}
@@ -119,6 +119,7 @@ func (p *Page) Eval(js string, args ...interface{}) (*proto.RuntimeRemoteObject,
 }
 
 // Evaluate js on the page.
+// If the js throws, ErrEval will be returned, its message contains the stack of the thrown error.
 func (p *Page) Evaluate(opts *EvalOptions) (res *proto.RuntimeRemoteObject, err error) {
 	var backoff utils.Sleeper
 
@@ -181,6 +182,8 @@ func (p *Page) evaluate(opts *EvalOptions) (*proto.RuntimeRemoteObject, error) {
 }
 
 // Expose fn to the page's window object with the name. The exposure survives reloads.
+// The exposed function returns a promise, it resolves with the json of the fn result,
+// or rejects with the error message if fn returns an error.
 // Call stop to unbind the fn.
 func (p *Page) Expose(name string, fn func(gson.JSON) (interface{}, error)) (stop func() error, err error) {
 	bind := "_" + utils.RandString(8)
@@ -216,8 +219,12 @@ func (p *Page) Expose(name string, fn func(gson.JSON) (interface{}, error)) (sto
 		if e.Name == bind {
 			payload := gson.NewFrom(e.Payload)
 			res, err := fn(payload.Get("req"))
+			var errMsg interface{}
+			if err != nil {
+				errMsg = err.Error()
+			}
 			code := fmt.Sprintf("(res, err) => %s(res, err)", payload.Get("cb").Str())
-			_, _ = p.Evaluate(Eval(code, res, err))
+			_, _ = p.Evaluate(Eval(code, res, errMsg))
 		}
 	})()
 
@@ -1,6 +1,7 @@
 package rod_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -58,6 +59,20 @@ func TestPageEval(t *testing.T) {
 	g.Has(err.Error(), `eval js error: ReferenceError: notExist is not defined`)
 }
 
+func TestPageEvalErrStack(t *testing.T) {
+	g := setup(t)
+
+	page := g.page.MustNavigate(g.blank())
+
+	_, err := page.Eval(`() => {
+		function thrower() { throw new Error('boom') }
+		thrower()
+	}`)
+	g.Is(err, &rod.ErrEval{})
+	g.Has(err.Error(), "eval js error: Error: boom")
+	g.Has(err.Error(), "at thrower")
+}
+
 func TestPageEvaluateRetry(t *testing.T) {
 	g := setup(t)
 
@@ -138,6 +153,22 @@ func TestPageExpose(t *testing.T) {
 	})
 }
 
+func TestPageExposeErr(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.blank()).MustWaitLoad()
+
+	page.MustExpose("exposedErr", func(j gson.JSON) (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+
+	g.Eq("boom", page.MustEval(`() => exposedErr().catch(e => e)`).Str())
+
+	_, err := page.Eval(`() => exposedErr()`)
+	g.Is(err, &rod.ErrEval{})
+	g.Has(err.Error(), "boom")
+}
+
 func TestObjectRelease(t *testing.T) {
 	g := setup(t)
 
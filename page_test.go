@@ -3,12 +3,20 @@ package rod_test
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
 	"image/png"
+	"io/ioutil"
 	"math"
 	"net/http"
+	"net/http/cookiejar"
+	"net/url"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -74,6 +82,45 @@ func TestSetCookies(t *testing.T) {
 	})
 }
 
+func TestPageCookieJar(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Mux.HandleFunc("/check", func(rw http.ResponseWriter, r *http.Request) {
+		c, err := r.Cookie("session")
+		g.E(err)
+		g.E(rw.Write([]byte(c.Value)))
+	})
+
+	page := g.newPage(s.URL()).MustSetCookies(&proto.NetworkCookieParam{
+		Name:  "session",
+		Value: "a",
+		URL:   s.URL(),
+	})
+
+	client := &http.Client{Jar: page.MustCookieJar()}
+	res, err := client.Get(s.URL("/check"))
+	g.E(err)
+	defer func() { _ = res.Body.Close() }()
+	body, err := ioutil.ReadAll(res.Body)
+	g.E(err)
+	g.Eq("a", string(body))
+
+	u, _ := url.Parse(s.URL())
+	jar, _ := cookiejar.New(nil)
+	jar.SetCookies(u, []*http.Cookie{{Name: "session", Value: "b"}})
+	g.Eq("b", page.MustSetCookieJar(jar, s.URL()).MustCookies()[0].Value)
+
+	g.Nil(page.SetCookieJar(jar, nil))
+	g.Err(page.SetCookieJar(jar, []string{"://"}))
+	g.Err(page.CookieJar([]string{"://"}))
+
+	g.mc.stubErr(1, proto.TargetGetTargetInfo{})
+	g.Err(page.CookieJar(nil))
+	g.mc.stubErr(1, proto.NetworkGetCookies{})
+	g.Err(page.CookieJar(nil))
+}
+
 func TestSetExtraHeaders(t *testing.T) {
 	g := setup(t)
 
@@ -109,6 +156,31 @@ func TestSetExtraHeaders(t *testing.T) {
 	}
 }
 
+func TestSetExtraHeadersClear(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+
+	wg := sync.WaitGroup{}
+	var header http.Header
+	s.Mux.HandleFunc("/", func(rw http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		wg.Done()
+	})
+
+	p := g.newPage()
+	defer p.MustSetExtraHeaders("a", "1")()
+
+	// an empty dict clears the previously set headers
+	defer p.MustSetExtraHeaders()()
+
+	wg.Add(1)
+	p.MustNavigate(s.URL())
+	wg.Wait()
+
+	g.Eq(header.Get("a"), "")
+}
+
 func TestSetUserAgent(t *testing.T) {
 	g := setup(t)
 
@@ -131,6 +203,19 @@ func TestSetUserAgent(t *testing.T) {
 
 	g.Eq(ua, "Mozilla/5.0 (Macintosh; Intel Mac OS X 11_0_0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/87.0.4280.88 Safari/537.36")
 	g.Eq(lang, "en")
+
+	wg.Add(1)
+	p := g.newPage().MustSetUserAgent(&proto.NetworkSetUserAgentOverride{
+		UserAgent:      "test-agent",
+		AcceptLanguage: "zh-CN",
+		Platform:       "test-platform",
+	}).MustNavigate(s.URL())
+	wg.Wait()
+
+	g.Eq(ua, "test-agent")
+	g.Eq(lang, "zh-CN")
+	g.Eq("zh-CN", p.MustEval(`() => navigator.language`).Str())
+	g.Eq("test-platform", p.MustEval(`() => navigator.platform`).Str())
 }
 
 func TestPageHTML(t *testing.T) {
@@ -144,6 +229,18 @@ func TestPageHTML(t *testing.T) {
 	g.Err(p.HTML())
 }
 
+func TestPageHTMLLiveDOM(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve().Route("/", ".html",
+		`<html><body><script>document.body.append(document.createElement('main'))</script></body></html>`)
+
+	p := g.newPage(s.URL()).MustWaitLoad()
+	html := p.MustHTML()
+	g.Has(html, "<main></main>")
+	g.Eq(html[:6], "<html>")
+}
+
 func TestMustWaitElementsMoreThan(t *testing.T) {
 	g := setup(t)
 
@@ -187,6 +284,30 @@ func TestPageContext(t *testing.T) {
 	_, _ = g.page.Timeout(time.Second).Timeout(time.Hour).CancelTimeout().Element("not-exist")
 }
 
+func TestPageTimeoutClones(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.srcFile("fixtures/click.html"))
+
+	_, err := p.Timeout(100 * time.Millisecond).Element("not-exist")
+	g.Is(err, context.DeadlineExceeded)
+
+	// the page itself is not affected
+	p.MustElement("button")
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clone := p.Timeout(time.Minute)
+			g.Eq(clone.MustEval(`n => n`, i).Int(), i)
+			clone.MustElement("button")
+		}(i)
+	}
+	wg.Wait()
+}
+
 func TestPageActivate(t *testing.T) {
 	g := setup(t)
 
@@ -247,6 +368,79 @@ func TestSetViewport(t *testing.T) {
 	page2 := g.newPage(g.blank())
 	res = page2.MustEval(`() => [window.innerWidth, window.innerHeight]`)
 	g.Neq(int(317), res.Get("0").Int())
+
+	// clear the override
+	g.E(page.SetViewport(nil))
+	res = page.MustEval(`() => [window.innerWidth, window.innerHeight]`)
+	g.Neq(int(317), res.Get("0").Int())
+
+	g.mc.stubErr(1, proto.EmulationClearDeviceMetricsOverride{})
+	g.Err(page.SetViewport(nil))
+}
+
+func TestSetGeolocation(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve().Route("/", ".html", `<html></html>`)
+
+	g.browser.MustGrantPermissions("*", proto.BrowserPermissionTypeGeolocation)
+	defer g.browser.MustResetPermissions()
+
+	page := g.newPage(s.URL()).MustSetGeolocation(51.5, -0.12)
+
+	res := page.MustEval(`() => new Promise(r => navigator.geolocation.getCurrentPosition(
+		p => r([p.coords.latitude, p.coords.longitude, p.coords.accuracy])
+	))`)
+	g.Eq(51.5, res.Get("0").Num())
+	g.Eq(-0.12, res.Get("1").Num())
+	g.Eq(1, res.Get("2").Int())
+
+	g.E(page.SetGeolocation(nil))
+	g.False(page.LoadState(&proto.EmulationSetGeolocationOverride{}))
+}
+
+func TestSetTimezone(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.blank())
+
+	tz := func() string {
+		return page.MustEval(`() => Intl.DateTimeFormat().resolvedOptions().timeZone`).Str()
+	}
+
+	page.MustSetTimezone("America/New_York")
+	g.Eq("America/New_York", tz())
+
+	page.MustSetTimezone("Asia/Tokyo")
+	g.Eq("Asia/Tokyo", tz())
+
+	g.Err(page.SetTimezone("Not/Exists"))
+
+	page.MustSetTimezone("")
+	g.Neq("Asia/Tokyo", tz())
+}
+
+func TestEmulateMedia(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.blank())
+
+	match := func(q string) bool {
+		return page.MustEval(`q => matchMedia(q).matches`, q).Bool()
+	}
+
+	page.MustEmulateMedia("print")
+	g.True(match("print"))
+
+	g.E(page.EmulateMedia(&proto.EmulationSetEmulatedMedia{
+		Features: []*proto.EmulationMediaFeature{{Name: "prefers-color-scheme", Value: "dark"}},
+	}))
+	g.True(match("(prefers-color-scheme: dark)"))
+	g.False(match("print"))
+
+	g.E(page.EmulateMedia(nil))
+	g.True(match("screen"))
+	g.False(match("(prefers-color-scheme: dark)"))
 }
 
 func TestSetDocumentContent(t *testing.T) {
@@ -304,6 +498,36 @@ func TestSetDocumentContent(t *testing.T) {
 	g.Eq(page.MustElement("div").MustText(), "💪")
 }
 
+func TestPageSetContent(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/js/a.js", ".js", `window.a = 1`)
+
+	p := g.newPage(s.URL("/js/a.js"))
+
+	p.MustSetContent(`<!DOCTYPE html><html><head><script src="a.js"></script></head><body><p>ok</p></body></html>`, s.URL("/js/"))
+	g.Eq(p.MustInfo().URL, "about:blank")
+	g.Eq(p.MustEval(`() => window.a`).Int(), 1)
+	g.Eq(p.MustEval(`() => document.compatMode`).Str(), "CSS1Compat")
+	g.Eq(p.MustElement("p").MustText(), "ok")
+	g.Eq(p.MustEval(`() => document.baseURI`).Str(), s.URL("/js/"))
+
+	p.MustSetContent(`<div>"a" & b</div>`, `x" y`)
+	g.Eq(p.MustElement("div").MustText(), `"a" & b`)
+	g.Eq(*p.MustElement("base").MustAttribute("href"), `x" y`)
+
+	p.MustSetContent(`<div>no base</div>`, "")
+	g.False(p.MustHas("base"))
+	g.False(p.MustHas("p"))
+
+	g.mc.stubErr(1, proto.PageNavigate{})
+	g.Err(p.SetContent("", ""))
+
+	g.mc.stubErr(1, proto.PageSetDocumentContent{})
+	g.Err(p.SetContent("", ""))
+}
+
 func TestEmulateDevice(t *testing.T) {
 	g := setup(t)
 
@@ -321,6 +545,10 @@ func TestEmulateDevice(t *testing.T) {
 		"Mozilla/5.0 (iPhone; CPU iPhone OS 13_2_3 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/13.0.3 Mobile/15E148 Safari/604.1",
 		res.Get("2").String(),
 	)
+	res = page.MustEval(`() => [window.devicePixelRatio, navigator.maxTouchPoints]`)
+	g.Eq(2, res.Get("0").Int())
+	g.Eq(5, res.Get("1").Int())
+
 	g.Panic(func() {
 		g.mc.stubErr(1, proto.EmulationSetDeviceMetricsOverride{})
 		page.MustEmulate(devices.IPad)
@@ -329,6 +557,10 @@ func TestEmulateDevice(t *testing.T) {
 		g.mc.stubErr(1, proto.EmulationSetTouchEmulationEnabled{})
 		page.MustEmulate(devices.IPad)
 	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.NetworkSetUserAgentOverride{})
+		page.MustEmulate(devices.IPad)
+	})
 }
 
 func TestPageCloseErr(t *testing.T) {
@@ -413,6 +645,18 @@ func TestPageWait(t *testing.T) {
 	})
 }
 
+func TestPageWaitJS(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.blank())
+	page.MustEval(`() => setTimeout(() => window.appLoaded = true, 200)`)
+	page.MustWait(`(k) => window[k] === true`, "appLoaded")
+
+	err := page.Wait(rod.Eval(`() => window.store.state.ready`))
+	g.Is(err, &rod.ErrEval{})
+	g.Has(err.Error(), "TypeError")
+}
+
 func TestPageNavigateBlank(t *testing.T) {
 	g := setup(t)
 
@@ -428,6 +672,30 @@ func TestPageWaitNavigation(t *testing.T) {
 	wait()
 }
 
+func TestPageWaitNavigated(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/", ".html", `<html><a href="/next">full</a><button onclick="history.pushState({}, '', '/spa')">spa</button></html>`)
+	s.Route("/next", ".html", `<html>next</html>`)
+
+	p := g.newPage(s.URL())
+
+	wait := p.MustWaitNavigated()
+	p.MustElement("button").MustClick()
+	wait()
+	g.Has(p.MustInfo().URL, "/spa")
+
+	wait = p.MustWaitNavigated()
+	p.MustElement("a").MustClick()
+	wait()
+	g.Has(p.MustInfo().URL, "/next")
+
+	w, cancel := p.WaitNavigated()
+	cancel()
+	g.Is(w(), context.Canceled)
+}
+
 func TestPageWaitRequestIdle(t *testing.T) {
 	g := setup(t)
 
@@ -490,6 +758,54 @@ func TestPageWaitRequestIdle(t *testing.T) {
 	})
 }
 
+func TestPageWaitRequestIdleIncludes(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+
+	sleep := time.Second
+
+	s.Route("/api", "")
+	s.Mux.HandleFunc("/beacon", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(g.Context(), sleep)
+		defer cancel()
+		<-ctx.Done()
+	})
+	s.Route("/", ".html", `<html></html>`)
+
+	page := g.newPage(s.URL()).MustWaitLoad()
+
+	// only the requests that match the includes will keep the page busy
+	wait := page.WaitRequestIdle(300*time.Millisecond, []string{"/api"}, nil)
+	page.MustEval(`() => { fetch('/beacon'); fetch('/api') }`)
+	start := time.Now()
+	wait()
+	g.Lt(time.Since(start), sleep)
+}
+
+func TestPageWaitRequestIdleExcludes(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+
+	timeout := 2 * time.Second
+
+	s.Route("/api", "")
+	s.Mux.HandleFunc("/stream", func(w http.ResponseWriter, r *http.Request) {
+		<-g.Context().Done() // never finishes during the test
+	})
+	s.Route("/", ".html", `<html></html>`)
+
+	page := g.newPage(s.URL()).MustWaitLoad()
+
+	// the excluded requests won't keep the page busy, even if they never finish
+	wait := page.Timeout(timeout).WaitRequestIdle(300*time.Millisecond, nil, []string{"/stream"})
+	page.MustEval(`() => { fetch('/stream'); fetch('/api') }`)
+	start := time.Now()
+	wait()
+	g.Lt(time.Since(start), timeout)
+}
+
 func TestPageWaitIdle(t *testing.T) {
 	g := setup(t)
 
@@ -500,6 +816,50 @@ func TestPageWaitIdle(t *testing.T) {
 	g.True(p.MustHas("[a=ok]"))
 }
 
+func TestPageWaitStable(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank()).MustWaitLoad()
+	p.MustEval(`() => {
+		let i = 0
+		const t = setInterval(() => {
+			document.body.append(document.createElement('p'))
+			if (++i === 5) clearInterval(t)
+		}, 50)
+	}`)
+
+	p.MustWaitStable(300 * time.Millisecond)
+	g.Len(p.MustElements("p"), 5)
+
+	p.MustEval(`() => setInterval(() => document.body.setAttribute('n', Math.random()), 10)`)
+	err := p.Timeout(300 * time.Millisecond).WaitStable(100 * time.Millisecond)
+	g.Is(err, context.DeadlineExceeded)
+}
+
+func TestPageWaitInvisible(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank()).MustWaitLoad()
+	p.MustEval(`() => document.body.innerHTML = '<div class="a">1</div><div class="a">2</div>' +
+		'<div class="b" style="display: none"></div>'`)
+
+	// no element matches or all are invisible
+	p.MustWaitInvisible(".none")
+	p.MustWaitInvisible(".b")
+
+	p.MustEval(`() => {
+		const list = document.querySelectorAll('.a')
+		setTimeout(() => list[0].remove(), 30)
+		setTimeout(() => list[1].style.visibility = 'hidden', 60)
+	}`)
+	p.MustWaitInvisible(".a")
+	g.Len(p.MustElements(".a"), 1)
+
+	p.MustEval(`() => document.body.innerHTML = '<div class="c">c</div>'`)
+	err := p.Timeout(100 * time.Millisecond).WaitInvisible(".c")
+	g.Is(err, context.DeadlineExceeded)
+}
+
 func TestPageEventSession(t *testing.T) {
 	g := setup(t)
 
@@ -580,6 +940,19 @@ func TestAlert(t *testing.T) {
 	handle(true, "")
 }
 
+func TestPageAutoHandleDialogs(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.blank())
+
+	stop := page.AutoHandleDialogs(true, "ok")
+	defer stop()
+
+	page.MustEval(`() => alert('a')`)
+	g.True(page.MustEval(`() => confirm('b')`).Bool())
+	g.Eq("ok", page.MustEval(`() => prompt('c')`).Str())
+}
+
 func TestPageHandleFileDialog(t *testing.T) {
 	g := setup(t)
 
@@ -629,6 +1002,30 @@ func TestPageScreenshot(t *testing.T) {
 	})
 }
 
+func TestPageScreenshotJpeg(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/click.html"))
+	p.MustElement("button")
+
+	shot := func(quality int) []byte {
+		data, err := p.Screenshot(false, &proto.PageCaptureScreenshot{
+			Format:  proto.PageCaptureScreenshotFormatJpeg,
+			Quality: gson.Int(quality),
+		})
+		g.E(err)
+		return data
+	}
+
+	high, low := shot(100), shot(10)
+
+	img, err := jpeg.Decode(bytes.NewBuffer(low))
+	g.E(err)
+	g.Eq(1280, img.Bounds().Dx())
+	g.Eq(800, img.Bounds().Dy())
+	g.Lt(len(low), len(high))
+}
+
 func TestScreenshotFullPage(t *testing.T) {
 	g := setup(t)
 
@@ -669,6 +1066,114 @@ func TestScreenshotFullPage(t *testing.T) {
 	})
 }
 
+func TestScreenshotFullPageStitch(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/page", ".html", `<html><body style="margin: 0">
+		<div style="height: 16000px; background: #f00"></div>
+		<div style="height: 800px; background: #0f0"></div>
+		<div style="height: 3200px; background: #00f"></div>
+	</body></html>`)
+
+	p := g.newPage().MustSetViewport(100, 100, 1, false)
+	p.MustNavigate(s.URL("/page")).MustWaitLoad()
+	p.MustEval(`() => window.scrollTo(0, 10)`)
+
+	color := func(img image.Image, y int) string {
+		r, g, b, _ := img.At(50, y).RGBA()
+		return fmt.Sprintf("%d,%d,%d", r>>8, g>>8, b>>8)
+	}
+
+	img, err := png.Decode(bytes.NewBuffer(p.MustScreenshotFullPage()))
+	g.E(err)
+	g.Eq(img.Bounds().Dx(), 100)
+	g.Eq(img.Bounds().Dy(), 20000)
+	g.Eq(color(img, 0), "255,0,0")
+	g.Eq(color(img, 15999), "255,0,0")
+	g.Eq(color(img, 16000), "0,255,0")
+	g.Eq(color(img, 16384), "0,255,0")
+	g.Eq(color(img, 16800), "0,0,255")
+	g.Eq(color(img, 19999), "0,0,255")
+
+	// the scroll position and the viewport should be restored
+	res := p.MustEval(`() => ({y: scrollY, h: innerHeight})`)
+	g.Eq(res.Get("y").Int(), 10)
+	g.Eq(res.Get("h").Int(), 100)
+
+	data, err := p.Screenshot(true, &proto.PageCaptureScreenshot{
+		Format:  proto.PageCaptureScreenshotFormatJpeg,
+		Quality: gson.Int(50),
+	})
+	g.E(err)
+	img, err = jpeg.Decode(bytes.NewBuffer(data))
+	g.E(err)
+	g.Eq(img.Bounds().Dy(), 20000)
+
+	_, err = p.Screenshot(true, &proto.PageCaptureScreenshot{Format: proto.PageCaptureScreenshotFormatWebp})
+	g.Is(err, &rod.ErrStitchFormat{})
+	g.Eq(err.Error(), "the stitched screenshot only supports png and jpeg, but got: webp")
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.Screenshot(true, nil))
+
+	g.mc.stubErr(2, proto.RuntimeCallFunctionOn{})
+	g.Err(p.Screenshot(true, nil))
+
+	g.mc.stubErr(3, proto.RuntimeCallFunctionOn{})
+	g.Err(p.Screenshot(true, nil))
+
+	g.mc.stubErr(1, proto.PageCaptureScreenshot{})
+	g.Err(p.Screenshot(true, nil))
+
+	// the max height is in device pixels, so the tiles are shorter for a larger device scale factor
+	p.MustSetViewport(100, 100, 2, false)
+	img, err = png.Decode(bytes.NewBuffer(p.MustScreenshotFullPage()))
+	g.E(err)
+	g.Eq(img.Bounds().Dx(), 200)
+	g.Eq(img.Bounds().Dy(), 40000)
+	g.Eq(color(img, 31999), "255,0,0")
+	g.Eq(color(img, 32000), "0,255,0")
+	g.Eq(color(img, 33600), "0,0,255")
+
+	g.mc.stub(1, proto.PageCaptureScreenshot{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(proto.PageCaptureScreenshotResult{Data: []byte("not png")}), nil
+	})
+	g.Err(p.Screenshot(true, nil))
+
+	// the device pixel ratio of the display is used if the device scale factor isn't set
+	p.MustSetViewport(100, 100, 0, false)
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.Screenshot(true, nil))
+}
+
+func TestPageScreencast(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank()).MustWaitLoad()
+	p.MustEval(`() => setInterval(() => {
+		document.body.style.background = '#' + Math.floor(Math.random() * 0xffffff).toString(16).padStart(6, '0')
+	}, 30)`)
+
+	frames, stop := p.MustScreencast(&proto.PageStartScreencast{
+		Format:   proto.PageStartScreencastFormatJpeg,
+		MaxWidth: gson.Int(320),
+	})
+
+	for i := 0; i < 3; i++ {
+		img, err := jpeg.Decode(bytes.NewBuffer(<-frames))
+		g.E(err)
+		g.Lte(img.Bounds().Dx(), 320)
+	}
+
+	stop()
+	for range frames {
+	}
+
+	g.mc.stubErr(1, proto.PageStartScreencast{})
+	g.Panic(func() { p.MustScreencast(nil) })
+}
+
 func TestScreenshotFullPageInit(t *testing.T) {
 	g := setup(t)
 
@@ -690,6 +1195,346 @@ func TestPageConsoleLog(t *testing.T) {
 	g.Eq(`1 map[b:[test]]`, p.MustObjectsToJSON(e.Args).Join(" "))
 }
 
+func TestPageConsole(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/page", ".html", `<html><script src="/a.js"></script></html>`)
+	s.Route("/a.js", ".js", `
+console.error("err", 1, true, null, undefined, NaN, { a: 1 })`)
+	s.Mux.HandleFunc("/not-found", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	})
+
+	p := g.newPage(g.blank())
+	events, cancel := p.Console()
+
+	next := func(text string) *rod.ConsoleMessage {
+		for msg := range events {
+			if strings.Contains(msg.Text, text) {
+				return msg
+			}
+		}
+		return nil
+	}
+
+	p.MustNavigate(s.URL("/page"))
+	msg := next("err")
+	g.Eq(msg.Level, "error")
+	g.Eq(msg.Text, "err 1 true null undefined NaN Object")
+	g.Eq(msg.URL, s.URL("/a.js"))
+	g.Eq(msg.LineNumber, 1)
+	g.Len(msg.Args, 7)
+	g.Eq(msg.Args[6].Preview.Properties[0].Name, "a")
+
+	p.MustEval(`() => fetch('/not-found')`)
+	msg = next("404")
+	g.Eq(msg.Level, "error")
+	g.Eq(msg.URL, s.URL("/not-found"))
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestPageFailOnConsoleError(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/page", ".html", `<html><script src="/a.js"></script></html>`)
+	s.Route("/a.js", ".js", `function foo() {
+	console.error("bad", 1)
+}
+foo()
+console.log("ok")
+setTimeout(() => { throw new Error("boom") })
+setTimeout(() => { throw "str" })`)
+
+	p := g.newPage(g.blank())
+	check, cancel := p.FailOnConsoleError()
+	defer cancel()
+
+	g.Nil(check())
+
+	p.MustNavigate(s.URL("/page"))
+
+	var err *rod.ErrConsoleErrors
+	g.E(utils.Retry(g.Context(), rod.DefaultSleeper(), func() (bool, error) {
+		return errors.As(check(), &err) && len(err.Errors) == 3, nil
+	}))
+
+	g.Regex(`\Abad 1\n    at foo \(.+/a\.js:2:\d+\)\n    at <anonymous> \(.+/a\.js:4:1\)\z`, err.Errors[0])
+	g.Has(err.Errors[1], "Error: boom\n    at ")
+	g.Has(err.Errors[2], "Uncaught str")
+	g.Is(err, &rod.ErrConsoleErrors{})
+	g.Has(err.Error(), "page has 3 console error(s):\nbad 1")
+}
+
+func TestPageEachRequestFailed(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Mux.HandleFunc("/hang", func(rw http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	p := g.newPage(g.blank())
+
+	failed := make(chan *rod.RequestFailed)
+	cancel := p.EachRequestFailed(func(e *rod.RequestFailed) {
+		failed <- e
+	})
+	defer cancel()
+
+	p.MustEval(`() => { fetch('http://127.0.0.1:1/refused').catch(() => {}) }`)
+	e := <-failed
+	g.Eq(e.URL, "http://127.0.0.1:1/refused")
+	g.Eq(e.Type, proto.NetworkResourceTypeFetch)
+	g.Eq(e.ErrorText, "net::ERR_CONNECTION_REFUSED")
+	g.False(e.Canceled)
+
+	p.MustEval(`url => {
+		const ctrl = new AbortController()
+		fetch(url, { signal: ctrl.signal }).catch(() => {})
+		setTimeout(() => ctrl.abort(), 100)
+	}`, s.URL("/hang"))
+	e = <-failed
+	g.Eq(e.URL, s.URL("/hang"))
+	g.True(e.Canceled)
+
+	// a http error status is not a failure
+	p.MustEval(`url => fetch(url).then(res => res.text())`, s.URL("/404"))
+}
+
+func TestPageWaitResponse(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/api", ".json", `{"a":1}`)
+	s.Route("/icon.png", slash("fixtures/icon.png"))
+	s.Mux.HandleFunc("/hang", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	p := g.newPage(g.blank())
+
+	wait := p.MustWaitResponse(`/api$`)
+	p.MustEval(`url => { fetch(url + '/not-match'); fetch(url + '/api') }`, s.URL())
+	res, body := wait()
+	g.Eq(res.Status, http.StatusOK)
+	g.Eq(res.URL, s.URL("/api"))
+	g.Eq(string(body), `{"a":1}`)
+
+	icon, err := ioutil.ReadFile(slash("fixtures/icon.png"))
+	g.E(err)
+	wait = p.MustWaitResponse(`icon`)
+	p.MustEval(`url => fetch(url)`, s.URL("/icon.png"))
+	_, body = wait()
+	g.Eq(body, icon)
+
+	w, _ := p.WaitResponse(`/hang`)
+	p.MustEval(`url => {
+		const ctrl = new AbortController()
+		fetch(url, { signal: ctrl.signal }).then(() => setTimeout(() => ctrl.abort(), 100))
+	}`, s.URL("/hang"))
+	_, _, err = w()
+	g.Is(err, &rod.ErrRequestFailed{})
+	g.Has(err.Error(), "request failed: net::ERR_ABORTED")
+
+	w, cancel := p.WaitResponse(`/api`)
+	cancel()
+	_, _, err = w()
+	g.Eq(err, context.Canceled)
+
+	wait = p.MustWaitResponse(`/api`)
+	p.MustEval(`url => fetch(url)`, s.URL("/api"))
+	g.mc.stubErr(1, proto.NetworkGetResponseBody{})
+	g.Panic(func() { wait() })
+
+	g.mc.stubErr(1, proto.NetworkGetResponseBody{})
+	g.Err(p.GetResponseBody("id"))
+}
+
+func TestPageWaitRequest(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/api", ".json", `{}`)
+
+	p := g.newPage(g.blank())
+
+	wait := p.MustWaitRequest(`/api$`)
+	p.MustEval(`url => {
+		fetch(url + '/not-match')
+		fetch(url + '/api', {
+			method: 'POST',
+			headers: { 'Content-Type': 'application/json' },
+			body: '{"a":1}',
+		})
+	}`, s.URL())
+	req := wait()
+	g.Eq(req.Method, http.MethodPost)
+	g.Eq(req.URL, s.URL("/api"))
+	g.Eq(req.Headers["Content-Type"].Str(), "application/json")
+	g.Eq(req.PostData, `{"a":1}`)
+
+	large := strings.Repeat("a", 1024*1024)
+	wait = p.MustWaitRequest(`/api`)
+	p.MustEval(`(url, body) => fetch(url, { method: 'POST', body })`, s.URL("/api"), large)
+	g.Eq(wait().PostData, large)
+
+	w, cancel := p.WaitRequest(`/api`)
+	cancel()
+	_, err := w()
+	g.Eq(err, context.Canceled)
+}
+
+func TestPageWaitDownload(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/d.txt", ".bin", "test content")
+	s.Mux.HandleFunc("/broken.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		_, _ = w.Write([]byte("part"))
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	})
+	s.Route("/page", ".html", `<html>
+		<a id="ok" href="/d.txt" download>ok</a>
+		<a id="broken" href="/broken.txt" download>broken</a>
+	</html>`)
+
+	b := g.browser.MustIncognito()
+	defer b.MustClose()
+
+	p := b.MustPage(s.URL("/page"))
+
+	wait, _ := p.WaitDownload()
+	_, err := wait()
+	g.Is(err, &rod.ErrDownloadPathNotSet{})
+	g.Eq(err.Error(), "the download path of the browser is not set")
+
+	dir := filepath.Join(os.TempDir(), "rod", "downloads", g.RandStr(8))
+	defer func() { _ = os.RemoveAll(dir) }()
+	b.MustSetDownloadPath(dir)
+
+	w := p.MustWaitDownload()
+	p.MustElement("#ok").MustClick()
+	path := w()
+	g.Eq(path, filepath.Join(dir, "d.txt"))
+	data, err := ioutil.ReadFile(path)
+	g.E(err)
+	g.Eq(string(data), "test content")
+
+	wait, _ = p.WaitDownload()
+	p.MustElement("#broken").MustClick()
+	_, err = wait()
+	g.Is(err, &rod.ErrDownloadCanceled{})
+	g.Eq(err.Error(), "download canceled: "+s.URL("/broken.txt"))
+
+	wait, cancel := p.WaitDownload()
+	cancel()
+	_, err = wait()
+	g.Eq(err, context.Canceled)
+
+	g.E(proto.BrowserSetDownloadBehavior{
+		Behavior:         proto.BrowserSetDownloadBehaviorBehaviorAllowAndName,
+		BrowserContextID: b.BrowserContextID,
+		DownloadPath:     dir,
+	}.Call(b))
+	w = p.MustWaitDownload()
+	p.MustElement("#ok").MustClick()
+	path = w()
+	g.Eq(filepath.Dir(path), dir)
+	g.Neq(filepath.Base(path), "d.txt")
+}
+
+func TestPageInfoOpener(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank())
+
+	info := p.MustInfo()
+	g.Eq(info.TargetID, p.TargetID)
+	g.Eq(info.Type, proto.TargetTargetInfoTypePage)
+	g.True(info.Attached)
+	g.Eq(info.OpenerID, proto.TargetTargetID(""))
+
+	wait := p.MustWaitOpen()
+	p.MustEval(`() => window.open('about:blank')`)
+	popup := wait()
+	defer popup.MustClose()
+
+	g.Eq(popup.MustInfo().OpenerID, p.TargetID)
+}
+
+func TestPageLocalStorage(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/", ".html", `<html></html>`)
+
+	p := g.newPage(s.URL()).MustWaitLoad()
+	p.MustEval(`() => localStorage.setItem('a', '1')`)
+	g.Eq(p.MustLocalStorage(), map[string]string{"a": "1"})
+
+	p.MustSetLocalStorage("b", "2")
+	g.Eq(p.MustEval(`() => localStorage.getItem('b')`).Str(), "2")
+
+	p.MustClearLocalStorage()
+	g.Eq(p.MustLocalStorage(), map[string]string{})
+
+	// the storage is kept across the navigations of the same origin
+	p.MustSetLocalStorage("c", "3")
+	p.MustNavigate(s.URL()).MustWaitLoad()
+	g.Eq(p.MustEval(`() => localStorage.getItem('c')`).Str(), "3")
+
+	g.mc.stubErr(1, proto.PageGetFrameTree{})
+	g.Err(p.LocalStorage())
+	g.mc.stubErr(1, proto.DOMStorageGetDOMStorageItems{})
+	g.Err(p.LocalStorage())
+	g.mc.stubErr(1, proto.PageGetFrameTree{})
+	g.Err(p.SetLocalStorage("a", "1"))
+	g.mc.stubErr(1, proto.PageGetFrameTree{})
+	g.Err(p.ClearLocalStorage())
+
+	blank := g.newPage()
+	err := blank.SetLocalStorage("a", "1")
+	g.Is(err, &rod.ErrNoStorageOrigin{})
+	g.Eq(err.Error(), "the frame has no security origin for the web storage")
+	g.Is(blank.ClearLocalStorage(), &rod.ErrNoStorageOrigin{})
+}
+
+func TestPageSessionStorage(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/", ".html", `<html><iframe src="/frame"></iframe></html>`)
+	s.Route("/frame", ".html", `<html></html>`)
+
+	p := g.newPage(s.URL()).MustWaitLoad()
+	p.MustEval(`() => sessionStorage.setItem('token', 'a')`)
+	g.Eq(p.MustSessionStorage(), map[string]string{"token": "a"})
+
+	// sessionStorage is isolated from localStorage
+	g.Eq(p.MustLocalStorage(), map[string]string{})
+
+	frame := p.MustElement("iframe").MustFrame()
+	frame.MustSetSessionStorage("b", "2")
+	g.Eq(frame.MustEval(`() => sessionStorage.getItem('b')`).Str(), "2")
+	g.Eq(frame.MustSessionStorage(), map[string]string{"b": "2", "token": "a"})
+
+	p.MustClearSessionStorage()
+	g.Eq(frame.MustSessionStorage(), map[string]string{})
+
+	blank := g.newPage()
+	_, err := blank.SessionStorage()
+	g.Is(err, &rod.ErrNoStorageOrigin{})
+}
+
 func TestFonts(t *testing.T) {
 	g := setup(t)
 
@@ -711,6 +1556,22 @@ func TestPagePDF(t *testing.T) {
 	g.E(err)
 	g.Nil(s.Close())
 
+	s, err = p.PDF(&proto.PagePrintToPDF{
+		Landscape:       true,
+		PrintBackground: true,
+		PaperWidth:      gson.Num(8.27),
+		PaperHeight:     gson.Num(11.7),
+		MarginTop:       gson.Num(0),
+	})
+	g.E(err)
+	bin, err := ioutil.ReadAll(s)
+	g.E(err)
+	g.Eq("%PDF", string(bin[:4]))
+
+	s, err = p.PDF(nil)
+	g.E(err)
+	g.Nil(s.Close())
+
 	p.MustPDF("")
 
 	g.Panic(func() {
@@ -751,6 +1612,16 @@ func TestPageNavigateErr(t *testing.T) {
 	})
 }
 
+func TestPageWaitLoadAfterLoaded(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank()).MustWaitLoad()
+
+	// the load event has already fired, the document.readyState should resolve it immediately
+	g.E(p.Timeout(time.Second).WaitLoad())
+	g.Eq("complete", p.MustEval(`() => document.readyState`).Str())
+}
+
 func TestPageWaitLoadErr(t *testing.T) {
 	g := setup(t)
 
@@ -787,6 +1658,243 @@ func TestPageNavigation(t *testing.T) {
 	g.Err(p.Reload())
 }
 
+func TestPageNavigationMainFrame(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.srcFile("fixtures/click-iframe.html")).MustWaitLoad()
+
+	wait := p.WaitNavigation(proto.PageLifecycleEventNameDOMContentLoaded)
+	done := make(chan struct{})
+	go func() {
+		wait()
+		close(done)
+	}()
+
+	// the navigation of the iframe doesn't count
+	waitIframe := p.EachEvent(func(e *proto.PageLifecycleEvent) bool {
+		return e.FrameID != p.FrameID && e.Name == proto.PageLifecycleEventNameDOMContentLoaded
+	})
+	p.MustElement("iframe").MustEval(`function (u) { this.src = u }`, g.srcFile("fixtures/selector.html"))
+	waitIframe()
+	p.MustEval(`() => {}`)
+	select {
+	case <-done:
+		g.Fatal("the navigation of the iframe resolved the wait")
+	default:
+	}
+	g.Regex("fixtures/selector.html$", p.MustElement("iframe").MustFrame().MustEval(`() => location.href`).Str())
+
+	// the navigation of the main frame does
+	p.MustEval(`u => location.href = u`, g.srcFile("fixtures/click.html"))
+	<-done
+}
+
+func TestPageNavigationNoHistoryEntry(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage()
+
+	g.Is(p.NavigateBack(), &rod.ErrNoHistoryEntry{})
+	g.Is(p.NavigateForward(), &rod.ErrNoHistoryEntry{})
+	g.Eq(p.NavigateBack().Error(), "no history entry to navigate to")
+
+	// the check is skipped for iframes
+	frame := g.page.MustNavigate(g.srcFile("fixtures/click-iframe.html")).MustElement("iframe").MustFrame()
+	g.E(frame.NavigateBack())
+	g.E(frame.NavigateForward())
+
+	g.mc.stubErr(1, proto.PageGetNavigationHistory{})
+	g.Err(p.NavigateBack())
+	g.mc.stubErr(1, proto.PageGetNavigationHistory{})
+	g.Err(p.NavigateForward())
+}
+
+func TestPageReloadIgnoreCache(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.srcFile("fixtures/click.html"))
+	p.MustEval(`() => window.reloaded = false`)
+	p.MustReloadIgnoreCache()
+	g.True(p.MustEval(`() => window.reloaded === undefined`).Bool())
+
+	g.mc.stubErr(1, proto.PageReload{})
+	g.Err(p.ReloadIgnoreCache())
+}
+
+func TestPageReloadCancel(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.srcFile("fixtures/prevent-close.html"))
+	page.MustElement("body").MustClick() // only focused page will handle beforeunload event
+
+	w, h := page.MustHandleDialog()
+	go func() {
+		w()
+		h(false, "")
+	}()
+	err := page.Reload()
+	g.Is(err, &rod.ErrReloadCanceled{})
+	g.Eq(err.Error(), "page reload canceled")
+
+	page.MustEval(`() => window.onbeforeunload = null`)
+	page.MustReload()
+}
+
+func TestPageReloadDismissOtherDialogs(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	proceed := make(chan struct{})
+	count := 0
+	s.Mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count > 1 {
+			<-proceed // hold the reload until the dialog is dismissed
+		}
+		g.HandleHTTP(".html", `<html>ok</html>`)(w, r)
+	})
+
+	page := g.newPage(s.URL("/page"))
+	page.MustEval(`() => setTimeout(() => confirm('ok'), 300)`)
+
+	w, h := page.MustHandleDialog()
+	go func() {
+		g.Eq(w().Type, proto.PageDialogTypeConfirm)
+		h(false, "")
+		close(proceed)
+	}()
+	g.E(page.Reload())
+}
+
+func TestPageMetrics(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.srcFile("fixtures/click.html")).MustWaitLoad()
+
+	metrics := p.MustMetrics()
+	for _, name := range []string{"LayoutDuration", "ScriptDuration", "JSHeapUsedSize", "Timestamp"} {
+		_, has := metrics[name]
+		g.True(has)
+	}
+	g.Gt(metrics["JSHeapUsedSize"], 0)
+
+	g.mc.stubErr(1, proto.PerformanceGetMetrics{})
+	g.Err(p.Metrics())
+}
+
+func TestPageTracing(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank())
+
+	stop := p.MustStartTracing()
+	p.MustNavigate(g.srcFile("fixtures/click.html")).MustWaitLoad()
+	trace := gson.New(stop())
+	g.Gt(len(trace.Get("traceEvents").Arr()), 0)
+
+	g.mc.stubErr(1, proto.TracingStart{})
+	g.Err(p.StartTracing(nil))
+
+	s, err := p.Timeout(300 * time.Millisecond).StartTracing([]string{"devtools.timeline"})
+	g.E(err)
+	g.mc.stubErr(1, proto.TracingEnd{})
+	g.Err(s())
+
+	// the tracingComplete event never comes
+	g.mc.stub(1, proto.TracingEnd{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(nil), nil
+	})
+	_, err = s()
+	g.Is(err, context.DeadlineExceeded)
+
+	g.E(proto.TracingEnd{}.Call(p))
+}
+
+func TestPageJSCoverage(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/", ".html", `<html><script src="/a.js"></script></html>`)
+	s.Route("/a.js", ".js", `function used() { return 1 }
+function unused() { return 2 }
+used()`)
+
+	p := g.newPage(g.blank())
+
+	stop := p.MustStartJSCoverage()
+	p.MustNavigate(s.URL()).MustWaitLoad()
+	p.MustEval(`() => eval("1 + 1")`)
+	list := stop()
+
+	g.Len(list, 1)
+	cov := list[0]
+	g.Eq(cov.URL, s.URL("/a.js"))
+	g.Has(cov.Source, "function unused()")
+
+	counts := map[string]int{}
+	for _, fn := range cov.Functions {
+		counts[fn.FunctionName] = fn.Ranges[0].Count
+	}
+	g.Eq(counts["used"], 1)
+	g.Eq(counts["unused"], 0)
+
+	g.mc.stubErr(1, proto.ProfilerStartPreciseCoverage{})
+	g.Err(p.StartJSCoverage())
+
+	stopErr := func(req proto.Request) {
+		stop, err := p.StartJSCoverage()
+		g.E(err)
+		p.MustNavigate(s.URL()).MustWaitLoad()
+		g.mc.stubErr(1, req)
+		g.Err(stop())
+	}
+	stopErr(proto.ProfilerTakePreciseCoverage{})
+	stopErr(proto.ProfilerStopPreciseCoverage{})
+	stopErr(proto.DebuggerGetScriptSource{})
+}
+
+func TestPageCSSCoverage(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/", ".html", `<html><link rel="stylesheet" href="/a.css"><div class="used"></div></html>`)
+	s.Route("/a.css", ".css", `.used { color: red; }
+.unused { color: blue; }`)
+
+	p := g.newPage(g.blank())
+
+	stop := p.MustStartCSSCoverage()
+	p.MustNavigate(s.URL()).MustWaitLoad()
+	list := stop()
+
+	g.Len(list, 1)
+	cov := list[0]
+	g.Eq(cov.Header.SourceURL, s.URL("/a.css"))
+	g.Len(cov.Rules, 2)
+
+	used := map[string]bool{}
+	for _, r := range cov.Rules {
+		used[cov.Source[int(r.StartOffset):int(r.EndOffset)]] = r.Used
+	}
+	g.Eq(used, map[string]bool{
+		".used { color: red; }":    true,
+		".unused { color: blue; }": false,
+	})
+
+	g.mc.stubErr(1, proto.CSSStartRuleUsageTracking{})
+	g.Err(p.StartCSSCoverage())
+
+	stopErr := func(req proto.Request) {
+		stop, err := p.StartCSSCoverage()
+		g.E(err)
+		p.MustNavigate(s.URL()).MustWaitLoad()
+		g.mc.stubErr(1, req)
+		g.Err(stop())
+	}
+	stopErr(proto.CSSStopRuleUsageTracking{})
+	stopErr(proto.CSSGetStyleSheetText{})
+}
+
 func TestPagePool(t *testing.T) {
 	g := setup(t)
 
@@ -799,12 +1907,54 @@ func TestPagePool(t *testing.T) {
 	})
 }
 
+func TestPagePoolGetWithContext(t *testing.T) {
+	g := setup(t)
+
+	pool := rod.NewPagePool(1)
+	create := func() *rod.Page { return g.browser.MustPage() }
+
+	p, err := pool.GetWithContext(g.Context(), create)
+	g.E(err)
+
+	// the pool is exhausted
+	ctx, cancel := context.WithTimeout(g.Context(), 100*time.Millisecond)
+	defer cancel()
+	_, err = pool.GetWithContext(ctx, create)
+	g.Is(err, context.DeadlineExceeded)
+
+	pool.Put(p.MustNavigate(g.blank()))
+	g.Eq(p, pool.Get(create))
+	pool.Put(p)
+
+	pool.Cleanup(func(p *rod.Page) {
+		p.MustClose()
+	})
+}
+
 func TestPageUseNonExistSession(t *testing.T) {
 	g := setup(t)
 
 	p := g.browser.PageFromSession("nonexist")
 	err := proto.PageClose{}.Call(p)
-	g.Eq(err, cdp.ErrSessionNotFound)
+	g.Is(err, cdp.ErrSessionNotFound)
+	g.Is(err, &rod.ErrPageClosed{})
+}
+
+func TestPageUseClosed(t *testing.T) {
+	g := setup(t)
+
+	p := g.browser.MustPage(g.blank())
+	p.MustClose()
+
+	_, err := p.Eval(`() => 1`)
+	g.Is(err, &rod.ErrPageClosed{})
+	g.Eq(err.Error(), "page closed")
+
+	// after the session is detached the calls are canceled by the session ctx
+	<-p.GetContext().Done()
+	_, err = p.Eval(`() => 1`)
+	g.Is(err, &rod.ErrPageClosed{})
+	g.Is(err, context.Canceled)
 }
 
 func TestPageElementFromObjectErr(t *testing.T) {
@@ -137,8 +137,21 @@ func (p *Page) HasR(selector, jsRegex string) (bool, *Element, error) {
 	return true, el.Sleeper(p.sleeper), nil
 }
 
+// WaitVisible retries until an element in the page that matches the CSS selector, then waits
+// until the element is visible, such as an element that is "display: none" until an animation finishes.
+// Check Element.WaitVisible for what visible means.
+func (p *Page) WaitVisible(selector string) (*Element, error) {
+	el, err := p.Element(selector)
+	if err != nil {
+		return nil, err
+	}
+	return el, el.WaitVisible()
+}
+
 // Element retries until an element in the page that matches the CSS selector, then returns
 // the matched element.
+// The retry interval starts at 100ms, check DefaultSleeper for details. Use Page.Timeout to limit the
+// total waiting time, or Page.Sleeper(nil) to disable the retry.
 func (p *Page) Element(selector string) (*Element, error) {
 	return p.ElementByJS(evalHelper(js.Element, selector))
 }
@@ -194,16 +207,23 @@ func (p *Page) ElementByJS(opts *EvalOptions) (*Element, error) {
 
 // Elements returns all elements that match the css selector
 func (p *Page) Elements(selector string) (Elements, error) {
-	return p.ElementsByJS(evalHelper(js.Elements, selector))
+	return p.elementsByJS(evalHelper(js.Elements, selector), true)
 }
 
 // ElementsX returns all elements that match the XPath selector
 func (p *Page) ElementsX(xpath string) (Elements, error) {
-	return p.ElementsByJS(evalHelper(js.ElementsX, xpath))
+	return p.elementsByJS(evalHelper(js.ElementsX, xpath), true)
 }
 
 // ElementsByJS returns the elements from the return value of the js
 func (p *Page) ElementsByJS(opts *EvalOptions) (Elements, error) {
+	return p.elementsByJS(opts, false)
+}
+
+// The array is resolved with a single call of proto.RuntimeGetProperties.
+// If sameJSCtx is true, all the nodes are known to belong to the js context of the page,
+// such as the result of querySelectorAll, so we can skip the round trip to resolve the context of each node.
+func (p *Page) elementsByJS(opts *EvalOptions, sameJSCtx bool) (Elements, error) {
 	res, err := p.Evaluate(opts.ByObject())
 	if err != nil {
 		return nil, err
@@ -234,6 +254,11 @@ func (p *Page) ElementsByJS(opts *EvalOptions) (Elements, error) {
 			return nil, &ErrExpectElements{val}
 		}
 
+		if sameJSCtx {
+			elemList = append(elemList, p.newElement(val))
+			continue
+		}
+
 		el, err := p.ElementFromObject(val)
 		if err != nil {
 			return nil, err
@@ -529,12 +554,12 @@ func (el *Element) Previous() (*Element, error) {
 
 // Elements returns all elements that match the css selector
 func (el *Element) Elements(selector string) (Elements, error) {
-	return el.ElementsByJS(evalHelper(js.Elements, selector))
+	return el.page.Context(el.ctx).elementsByJS(evalHelper(js.Elements, selector).This(el.Object), true)
 }
 
 // ElementsX returns all elements that match the XPath selector
 func (el *Element) ElementsX(xpath string) (Elements, error) {
-	return el.ElementsByJS(evalHelper(js.ElementsX, xpath))
+	return el.page.Context(el.ctx).elementsByJS(evalHelper(js.ElementsX, xpath).This(el.Object), true)
 }
 
 // ElementsByJS returns the elements from the return value of the js
@@ -24,6 +24,25 @@ func TestPageElements(t *testing.T) {
 	g.Eq("submit", list.Last().MustText())
 }
 
+func TestPageElementsMany(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank())
+	p.MustEval(`() => {
+		for (let i = 0; i < 300; i++) {
+			let el = document.createElement('p')
+			el.innerText = i
+			document.body.append(el)
+		}
+	}`)
+
+	list := p.MustElements("p")
+
+	g.Len(list, 300)
+	g.Eq(list.Last().MustText(), "299")
+	g.Len(p.MustElement("body").MustElementsX("p"), 300)
+}
+
 func TestPagesQuery(t *testing.T) {
 	g := setup(t)
 
@@ -412,6 +431,22 @@ func TestPageElementsByJS(t *testing.T) {
 	g.Err(p.Elements("button"))
 }
 
+func TestPageElementAsync(t *testing.T) {
+	g := setup(t)
+
+	page := g.page.MustNavigate(g.blank())
+	page.MustEval(`() => setTimeout(() => {
+		let el = document.createElement('div')
+		el.id = 'async'
+		el.innerText = 'ok'
+		document.body.append(el)
+	}, 200)`)
+
+	el, err := page.Timeout(5 * time.Second).Element("#async")
+	g.E(err)
+	g.Eq(el.MustText(), "ok")
+}
+
 func TestPageElementTimeout(t *testing.T) {
 	g := setup(t)
 
@@ -431,6 +466,15 @@ func TestPageElementMaxRetry(t *testing.T) {
 	g.Is(err, &utils.ErrMaxSleepCount{})
 }
 
+func TestPageElementNotFound(t *testing.T) {
+	g := setup(t)
+
+	page := g.page.MustNavigate(g.blank())
+	_, err := page.Sleeper(rod.NotFoundSleeper).Element("not-exists")
+	g.Is(err, &rod.ErrElementNotFound{})
+	g.Eq(err.Error(), "cannot find element")
+}
+
 func TestElementsOthers(t *testing.T) {
 	g := setup(t)
 
@@ -93,6 +93,7 @@ func NewPagePool(limit int) PagePool {
 }
 
 // Get a page from the pool. Use the PagePool.Put to make it reusable later.
+// It blocks until a page is available.
 func (pp PagePool) Get(create func() *Page) *Page {
 	p := <-pp
 	if p == nil {
@@ -101,7 +102,23 @@ func (pp PagePool) Get(create func() *Page) *Page {
 	return p
 }
 
-// Put a page back to the pool
+// GetWithContext is similar to PagePool.Get, but it returns the ctx error if the ctx is done
+// before a page is available.
+func (pp PagePool) GetWithContext(ctx context.Context, create func() *Page) (*Page, error) {
+	select {
+	case p := <-pp:
+		if p == nil {
+			p = create()
+		}
+		return p, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Put a page back to the pool.
+// Reusing a page saves the time to create a new target, you may want to reset it before putting it back,
+// such as navigating it to "about:blank".
 func (pp PagePool) Put(p *Page) {
 	pp <- p
 }
@@ -132,6 +149,7 @@ func NewBrowserPool(limit int) BrowserPool {
 }
 
 // Get a browser from the pool. Use the BrowserPool.Put to make it reusable later.
+// It blocks until a browser is available.
 func (bp BrowserPool) Get(create func() *Browser) *Browser {
 	p := <-bp
 	if p == nil {
@@ -140,6 +158,20 @@ func (bp BrowserPool) Get(create func() *Browser) *Browser {
 	return p
 }
 
+// GetWithContext is similar to BrowserPool.Get, but it returns the ctx error if the ctx is done
+// before a browser is available.
+func (bp BrowserPool) GetWithContext(ctx context.Context, create func() *Browser) (*Browser, error) {
+	select {
+	case p := <-bp:
+		if p == nil {
+			p = create()
+		}
+		return p, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // Put a browser back to the pool
 func (bp BrowserPool) Put(p *Browser) {
 	bp <- p